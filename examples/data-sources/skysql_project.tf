@@ -0,0 +1,9 @@
+# Retrieve the default project
+data "skysql_project" "default" {
+  is_default = true
+}
+
+# Retrieve a project by name
+data "skysql_project" "payments" {
+  name = "payments"
+}
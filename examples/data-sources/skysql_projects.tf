@@ -1,7 +1,11 @@
 # Retrieve the list of projects. Project is a way of grouping the services.
-# Note: Next release will make project_id optional in the create service api
 data "skysql_projects" "default" {}
 
 output "skysql_projects" {
   value = data.skysql_projects.default
 }
+
+# Retrieve the projects with a name starting with "payments"
+data "skysql_projects" "payments" {
+  name_regex = "^payments"
+}
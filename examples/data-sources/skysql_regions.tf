@@ -0,0 +1,8 @@
+# List the regions where services can be created on GCP
+data "skysql_regions" "gcp" {
+  cloud_provider = "gcp"
+}
+
+output "gcp_regions" {
+  value = [for region in data.skysql_regions.gcp.regions : region.name]
+}
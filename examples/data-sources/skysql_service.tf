@@ -1,3 +1,9 @@
 data "skysql_service" "default" {
   service_id = "dbpwf22338686"
-}
\ No newline at end of file
+}
+
+# Look up a service by name within a project
+data "skysql_service" "orders" {
+  name       = "orders-prod"
+  project_id = "f4a1b1a2-5a1c-4b8a-9d3c-2f7b6c1a0e11"
+}
@@ -0,0 +1,11 @@
+# List the ready services of a project deployed in GCP
+data "skysql_services" "default" {
+  project_id     = "f4a1b1a2-5a1c-4b8a-9d3c-2f7b6c1a0e11"
+  cloud_provider = "gcp"
+  name_regex     = "^prod-"
+  status         = "ready"
+}
+
+output "skysql_service_fqdns" {
+  value = { for service in data.skysql_services.default.services : service.name => service.fqdn }
+}
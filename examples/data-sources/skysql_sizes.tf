@@ -0,0 +1,13 @@
+# List the server sizes available for an es-single service on AWS
+data "skysql_sizes" "default" {
+  cloud_provider = "aws"
+  architecture   = "amd64"
+  topology       = "es-single"
+  type           = "server"
+}
+
+# The sizes are sorted from the smallest to the largest,
+# so the first match is the smallest size with at least 16 GB of memory
+locals {
+  size = [for size in data.skysql_sizes.default.sizes : size.name if size.memory >= 16][0]
+}
@@ -0,0 +1,8 @@
+# List the transactional topologies that support replication
+data "skysql_topologies" "transactional" {
+  service_type = "transactional"
+}
+
+output "replicated_topologies" {
+  value = [for topology in data.skysql_topologies.transactional.topologies : topology.name if topology.supports_replication]
+}
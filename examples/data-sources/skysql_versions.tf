@@ -1,2 +1,13 @@
 # List all SkySQL versions
 data "skysql_versions" "default" {}
+
+# Find the newest 10.6 version of the es-single topology
+data "skysql_versions" "es_single" {
+  topology           = "es-single"
+  product            = "server"
+  version_constraint = ">= 10.6, < 11"
+}
+
+output "version" {
+  value = data.skysql_versions.es_single.most_recent.name
+}
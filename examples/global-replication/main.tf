@@ -1,27 +1,23 @@
 provider "skysql" {}
 
-# Retrieve the list of available versions for each topology like es-single, es-replica, xpand etc
-data "skysql_versions" "default" {}
-
-
-# Filter the list of versions to only include  versions for the standalone topology
-locals {
-  sky_versions_filtered = [
-    for item in data.skysql_versions.default.versions : item if item.topology == "xpand"
-  ]
+# Retrieve the newest available version of the xpand topology
+data "skysql_versions" "default" {
+  topology = "xpand"
+  latest   = true
 }
 
-# Retrieve the list of projects. Project is a way of grouping the services.
-# Note: Next release will make project_id optional in the create service api
-data "skysql_projects" "default" {}
+# Retrieve the default project. Project is a way of grouping the services.
+data "skysql_project" "default" {
+  is_default = true
+}
 
-output "skysql_projects" {
-  value = data.skysql_projects.default
+output "skysql_project" {
+  value = data.skysql_project.default
 }
 
 # Create a service
 resource "skysql_service" "primary" {
-  project_id     = data.skysql_projects.default.projects[0].id
+  project_id     = data.skysql_project.default.id
   service_type   = "transactional"
   topology       = "xpand"
   cloud_provider = "gcp"
@@ -32,7 +28,7 @@ resource "skysql_service" "primary" {
   size           = "sky-2x8"
   storage        = 100
   ssl_enabled    = true
-  version        = local.sky_versions_filtered[0].name
+  version        = data.skysql_versions.default.most_recent.name
   # The service create is an asynchronous operation.
   # if you want to wait for the service to be created set wait_for_creation to true
   wait_for_creation = true
@@ -40,7 +36,7 @@ resource "skysql_service" "primary" {
 }
 
 resource "skysql_service" "replica" {
-  project_id          = data.skysql_projects.default.projects[0].id
+  project_id          = data.skysql_project.default.id
   service_type        = "transactional"
   topology            = "xpand"
   cloud_provider      = "gcp"
@@ -51,7 +47,7 @@ resource "skysql_service" "replica" {
   size                = "sky-2x8"
   storage             = 100
   ssl_enabled         = true
-  version             = local.sky_versions_filtered[0].name
+  version             = data.skysql_versions.default.most_recent.name
   primary_host        = skysql_service.primary.id
   replication_enabled = true
   # The service create is an asynchronous operation.
@@ -26,13 +26,9 @@ resource "skysql_service" "this" {
   # deletion_protection = false
 }
 
-data "skysql_service" "this" {
-  service_id = skysql_service.this.id
-}
-
 locals {
   # this should work for all topologies other than lakehouse
-  readwrite_port = [for p in data.skysql_service.this.endpoints[0].ports : p.port if p.purpose == "readwrite"][0]
+  readwrite_port = [for p in skysql_service.this.endpoints[0].ports : p.port if p.purpose == "readwrite"][0]
   skysql_domain  = "db.skysql.net"
 }
 
@@ -54,7 +50,7 @@ resource "google_compute_forwarding_rule" "this" {
   region                = var.region
   project               = var.project_id
   ip_address            = google_compute_address.this.id
-  target                = data.skysql_service.this.endpoints[0].endpoint_service
+  target                = skysql_service.this.endpoint_service
   network               = var.network
 }
 
@@ -83,7 +79,7 @@ resource "google_dns_managed_zone" "this" {
 resource "google_dns_record_set" "this" {
   count        = var.link_dns ? 1 : 0
   managed_zone = google_dns_managed_zone.this[0].name
-  name         = "${data.skysql_service.this.fqdn}."
+  name         = "${skysql_service.this.fqdn}."
   type         = "A"
   ttl          = 300
   rrdatas      = [google_compute_address.this.address]
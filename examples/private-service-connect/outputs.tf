@@ -1,6 +1,6 @@
 output "skysql_endpoint_service_id" {
   description = "SkySQL privatelink endpoint service id"
-  value       = data.skysql_service.this.endpoints[0].endpoint_service
+  value       = skysql_service.this.endpoint_service
 }
 
 output "psc_address" {
@@ -10,5 +10,5 @@ output "psc_address" {
 
 output "skysql_host" {
   description = "Hostname for private database connections"
-  value       = var.link_dns ? data.skysql_service.this.fqdn : google_compute_address.this.address
+  value       = var.link_dns ? skysql_service.this.fqdn : google_compute_address.this.address
 }
@@ -26,10 +26,6 @@ resource "skysql_service" "this" {
   # deletion_protection = false
 }
 
-data "skysql_service" "this" {
-  service_id = skysql_service.this.id
-}
-
 data "aws_subnets" "this" {
   filter {
     name   = "vpc-id"
@@ -39,7 +35,7 @@ data "aws_subnets" "this" {
 
 locals {
   # this should work for all topologies other than lakehouse
-  readwrite_port = [for p in data.skysql_service.this.endpoints[0].ports : p.port if p.purpose == "readwrite"][0]
+  readwrite_port = [for p in skysql_service.this.endpoints[0].ports : p.port if p.purpose == "readwrite"][0]
 }
 
 ###
@@ -70,7 +66,7 @@ resource "aws_security_group" "this" {
 ###
 resource "aws_vpc_endpoint" "this" {
   vpc_id            = var.vpc_id
-  service_name      = data.skysql_service.this.endpoints[0].endpoint_service
+  service_name      = skysql_service.this.endpoint_service
   vpc_endpoint_type = "Interface"
   subnet_ids        = data.aws_subnets.this.ids
 
@@ -1,6 +1,6 @@
 output "skysql_endpoint_service_id" {
   description = "SkySQL privatelink endpoint service id"
-  value       = data.skysql_service.this.endpoints[0].endpoint_service
+  value       = skysql_service.this.endpoint_service
 }
 
 output "aws_security_group_id" {
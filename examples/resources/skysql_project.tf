@@ -0,0 +1,8 @@
+# Create a project to group the services of a team
+resource "skysql_project" "default" {
+  name        = "team-orders"
+  description = "Services of the orders team"
+}
+
+# An existing project can be imported with its ID:
+# terraform import skysql_project.default f4a1b1a2-5a1c-4b8a-9d3c-2f7b6c1a0e11
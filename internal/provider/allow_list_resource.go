@@ -13,7 +13,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdkresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
 )
@@ -29,7 +28,7 @@ func NewServiceAllowListResource() resource.Resource {
 
 // ServiceAllowListResource defines the resource implementation.
 type ServiceAllowListResource struct {
-	client *skysql.Client
+	client skysql.API
 }
 
 // ServiceAllowListResourceModel describes the data source data model.
@@ -87,6 +86,8 @@ func (r *ServiceAllowListResource) Schema(ctx context.Context, req resource.Sche
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
+				Update: true,
+				Delete: true,
 			}),
 		},
 	}
@@ -98,12 +99,12 @@ func (r *ServiceAllowListResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*skysql.Client)
+	client, ok := req.ProviderData.(skysql.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -113,6 +114,9 @@ func (r *ServiceAllowListResource) Configure(ctx context.Context, req resource.C
 }
 
 func (r *ServiceAllowListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "skysql_allow_list.Create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data *ServiceAllowListResourceModel
 
 	// Read Terraform plan data into the model
@@ -128,9 +132,11 @@ func (r *ServiceAllowListResource) Create(ctx context.Context, req resource.Crea
 		allowListUpdateRequest[i].Comment = data.AllowList[i].Comment.ValueString()
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(data.ID.ValueString()))
+
 	allowListResp, err := r.client.UpdateServiceAllowListByID(ctx, data.ID.ValueString(), allowListUpdateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating service allow list", err.Error())
+		resp.Diagnostics.AddError("Error updating service allow list", errorDetail(err))
 		return
 	}
 
@@ -151,33 +157,25 @@ func (r *ServiceAllowListResource) Create(ctx context.Context, req resource.Crea
 
 	if data.WaitForCreation.ValueBool() {
 
-		createTimeout, diagsErr := data.Timeouts.Create(ctx, defaultCreateTimeout)
+		createTimeout, diagsErr := operationTimeout(ctx, data.Timeouts, timeoutCreate, defaultCreateTimeout)
 		if diagsErr != nil {
-			diagsErr.AddError("Error creating service", fmt.Sprintf("Unable to create service, got error: %s", err))
 			resp.Diagnostics.Append(diagsErr...)
+			return
 		}
 
-		err = sdkresource.RetryContext(ctx, createTimeout, func() *sdkresource.RetryError {
-
-			service, err := r.client.GetServiceByID(ctx, data.ID.ValueString())
-			if err != nil {
-				return sdkresource.NonRetryableError(fmt.Errorf("error retrieving service details: %v", err))
-			}
-
-			if service.Status != "ready" && service.Status != "failed" {
-				return sdkresource.RetryableError(fmt.Errorf("expected instance to be ready or failed state but was in state %s", service.Status))
-			}
-
-			return nil
-		})
+		err = newOperationWaiter(createTimeout, []string{"ready"}, serviceFailureStates).
+			Wait(ctx, data.ID.ValueString(), serviceStatus(r.client, data.ID.ValueString()))
 
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating service", fmt.Sprintf("Unable to update service, got error: %s", err))
+			resp.Diagnostics.AddError("Error updating service", fmt.Sprintf("Unable to update service, got error: %s", errorDetail(err)))
 		}
 	}
 }
 
 func (r *ServiceAllowListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "skysql_allow_list.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data *ServiceAllowListResourceModel
 
 	// Read Terraform prior state data into the model
@@ -187,9 +185,11 @@ func (r *ServiceAllowListResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(data.ID.ValueString()))
+
 	allowListResp, err := r.client.ReadServiceAllowListByID(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Can not find service", err.Error())
+		resp.Diagnostics.AddError("Can not find service", errorDetail(err))
 		return
 	}
 
@@ -212,6 +212,9 @@ func (r *ServiceAllowListResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 func (r *ServiceAllowListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "skysql_allow_list.Update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var plan *ServiceAllowListResourceModel
 	var state *ServiceAllowListResourceModel
 
@@ -234,6 +237,8 @@ func (r *ServiceAllowListResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(plan.ID.ValueString()))
+
 	allowListUpdateRequest := make([]provisioning.AllowListItem, len(plan.AllowList))
 	for i := range plan.AllowList {
 		allowListUpdateRequest[i].IPAddress = plan.AllowList[i].IPAddress.ValueString()
@@ -250,7 +255,7 @@ func (r *ServiceAllowListResource) Update(ctx context.Context, req resource.Upda
 
 			return
 		}
-		resp.Diagnostics.AddError("Error updating service allow list", err.Error())
+		resp.Diagnostics.AddError("Error updating service allow list", errorDetail(err))
 		return
 	}
 
@@ -271,33 +276,25 @@ func (r *ServiceAllowListResource) Update(ctx context.Context, req resource.Upda
 
 	if state.WaitForCreation.ValueBool() {
 
-		createTimeout, diagsErr := state.Timeouts.Create(ctx, defaultCreateTimeout)
+		updateTimeout, diagsErr := operationTimeout(ctx, state.Timeouts, timeoutUpdate, defaultUpdateTimeout)
 		if diagsErr != nil {
-			diagsErr.AddError("Error creating service", fmt.Sprintf("Unable to create service, got error: %s", err))
 			resp.Diagnostics.Append(diagsErr...)
+			return
 		}
 
-		err = sdkresource.RetryContext(ctx, createTimeout, func() *sdkresource.RetryError {
-
-			service, err := r.client.GetServiceByID(ctx, state.ID.ValueString())
-			if err != nil {
-				return sdkresource.NonRetryableError(fmt.Errorf("error retrieving service details: %v", err))
-			}
-
-			if service.Status != "ready" && service.Status != "failed" {
-				return sdkresource.RetryableError(fmt.Errorf("expected instance to be ready or failed state but was in state %s", service.Status))
-			}
-
-			return nil
-		})
+		err = newOperationWaiter(updateTimeout, []string{"ready"}, serviceFailureStates).
+			Wait(ctx, state.ID.ValueString(), serviceStatus(r.client, state.ID.ValueString()))
 
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating service", fmt.Sprintf("Unable to update service, got error: %s", err))
+			resp.Diagnostics.AddError("Error updating service", fmt.Sprintf("Unable to update service, got error: %s", errorDetail(err)))
 		}
 	}
 }
 
 func (r *ServiceAllowListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "skysql_allow_list.Delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data *ServiceAllowListResourceModel
 
 	// Read Terraform plan data into the model
@@ -307,6 +304,8 @@ func (r *ServiceAllowListResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(data.ID.ValueString()))
+
 	allowListUpdateRequest := make([]provisioning.AllowListItem, 0)
 
 	_, err := r.client.UpdateServiceAllowListByID(ctx, data.ID.ValueString(), allowListUpdateRequest)
@@ -319,7 +318,7 @@ func (r *ServiceAllowListResource) Delete(ctx context.Context, req resource.Dele
 
 			return
 		}
-		resp.Diagnostics.AddError("Error updating service allow list", err.Error())
+		resp.Diagnostics.AddError("Error updating service allow list", errorDetail(err))
 		return
 	}
 
@@ -336,28 +335,17 @@ func (r *ServiceAllowListResource) Delete(ctx context.Context, req resource.Dele
 
 	if data.WaitForCreation.ValueBool() {
 
-		createTimeout, diagsErr := data.Timeouts.Create(ctx, defaultCreateTimeout)
+		deleteTimeout, diagsErr := operationTimeout(ctx, data.Timeouts, timeoutDelete, defaultDeleteTimeout)
 		if diagsErr != nil {
-			diagsErr.AddError("Error deleting allowlist", fmt.Sprintf("Unable to delete service allowlist, got error: %s", err))
 			resp.Diagnostics.Append(diagsErr...)
+			return
 		}
 
-		err = sdkresource.RetryContext(ctx, createTimeout, func() *sdkresource.RetryError {
-
-			service, err := r.client.GetServiceByID(ctx, data.ID.ValueString())
-			if err != nil {
-				return sdkresource.NonRetryableError(fmt.Errorf("error retrieving service details: %v", err))
-			}
-
-			if service.Status != "ready" && service.Status != "failed" {
-				return sdkresource.RetryableError(fmt.Errorf("expected instance to be ready or failed state but was in state %s", service.Status))
-			}
-
-			return nil
-		})
+		err = newOperationWaiter(deleteTimeout, []string{"ready"}, serviceFailureStates).
+			Wait(ctx, data.ID.ValueString(), serviceStatus(r.client, data.ID.ValueString()))
 
 		if err != nil {
-			resp.Diagnostics.AddError("Error deleting allowlist", fmt.Sprintf("Unable to update allowlist, got error: %s", err))
+			resp.Diagnostics.AddError("Error deleting allowlist", fmt.Sprintf("Unable to update allowlist, got error: %s", errorDetail(err)))
 		}
 	}
 }
@@ -32,7 +32,7 @@ func NewAutonomousResource() resource.Resource {
 
 // AutonomousResource defines the resource implementation.
 type AutonomousResource struct {
-	client *skysql.Client
+	client skysql.API
 }
 
 // AutonomousResourceModel describes the data source data model.
@@ -193,12 +193,12 @@ func (r *AutonomousResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*skysql.Client)
+	client, ok := req.ProviderData.(skysql.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -208,6 +208,9 @@ func (r *AutonomousResource) Configure(ctx context.Context, req resource.Configu
 }
 
 func (r *AutonomousResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "skysql_autonomous.Create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data *AutonomousResourceModel
 
 	// Read Terraform plan data into the model
@@ -219,9 +222,11 @@ func (r *AutonomousResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(data.ServiceID.ValueString()))
+
 	service, err := r.client.GetServiceByID(ctx, data.ServiceID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Can not read service", err.Error())
+		resp.Diagnostics.AddError("Can not read service", errorDetail(err))
 		return
 	}
 
@@ -291,7 +296,7 @@ func (r *AutonomousResource) Create(ctx context.Context, req resource.CreateRequ
 	if len(request.Actions) > 0 {
 		actions, err := r.client.SetAutonomousActions(ctx, request)
 		if err != nil {
-			resp.Diagnostics.AddError("error creating skysql_autonomous resource", err.Error())
+			resp.Diagnostics.AddError("error creating skysql_autonomous resource", errorDetail(err))
 			return
 		}
 		resp.Diagnostics.Append(r.actionsResponseToData(actions, data)...)
@@ -307,6 +312,9 @@ func (r *AutonomousResource) Create(ctx context.Context, req resource.CreateRequ
 }
 
 func (r *AutonomousResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "skysql_autonomous.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data *AutonomousResourceModel
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -314,6 +322,8 @@ func (r *AutonomousResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(data.ServiceID.ValueString()))
+
 	_, err := r.client.GetServiceByID(ctx, data.ServiceID.ValueString())
 	if err != nil {
 		if errors.Is(err, skysql.ErrorServiceNotFound) {
@@ -324,13 +334,13 @@ func (r *AutonomousResource) Read(ctx context.Context, req resource.ReadRequest,
 
 			return
 		}
-		resp.Diagnostics.AddError("Can not read service", err.Error())
+		resp.Diagnostics.AddError("Can not read service", errorDetail(err))
 		return
 	}
 
 	actions, err := r.client.GetAutonomousActions(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("can not read skysql_autonomous resource", err.Error())
+		resp.Diagnostics.AddError("can not read skysql_autonomous resource", errorDetail(err))
 		return
 	}
 
@@ -360,7 +370,7 @@ func (r *AutonomousResource) actionsResponseToData(actions []autonomous.ActionRe
 			params := autonomous.AutoScaleDiskActionParams{}
 			err := json.Unmarshal(action.Params, &params)
 			if err != nil {
-				diags.AddError("can not read skysql_autonomous resource", err.Error())
+				diags.AddError("can not read skysql_autonomous resource", errorDetail(err))
 				return diags
 
 			}
@@ -372,7 +382,7 @@ func (r *AutonomousResource) actionsResponseToData(actions []autonomous.ActionRe
 			params := autonomous.AutoScaleNodesVerticalActionParams{}
 			err := json.Unmarshal(action.Params, &params)
 			if err != nil {
-				diags.AddError("can not read skysql_autonomous resource", err.Error())
+				diags.AddError("can not read skysql_autonomous resource", errorDetail(err))
 				return diags
 			}
 			data.AutoScaleNodesVerticalAction = types.ObjectValueMust(autoScaleNodesVerticalAttrTypes, map[string]attr.Value{
@@ -384,7 +394,7 @@ func (r *AutonomousResource) actionsResponseToData(actions []autonomous.ActionRe
 			params := autonomous.AutoScaleNodesHorizontalActionParams{}
 			err := json.Unmarshal(action.Params, &params)
 			if err != nil {
-				diags.AddError("can not read skysql_autonomous resource", err.Error())
+				diags.AddError("can not read skysql_autonomous resource", errorDetail(err))
 				return diags
 			}
 			data.AutoScaleNodesHorizontalAction = types.ObjectValueMust(autoScaleNodesHorizontalAttrTypes, map[string]attr.Value{
@@ -398,6 +408,9 @@ func (r *AutonomousResource) actionsResponseToData(actions []autonomous.ActionRe
 }
 
 func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "skysql_autonomous.Update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var plan *AutonomousResourceModel
 	var state *AutonomousResourceModel
 
@@ -413,6 +426,8 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(state.ServiceID.ValueString()))
+
 	service, err := r.client.GetServiceByID(ctx, state.ServiceID.ValueString())
 	if err != nil {
 		if errors.Is(err, skysql.ErrorServiceNotFound) {
@@ -423,7 +438,7 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 
 			return
 		}
-		resp.Diagnostics.AddError("Can not read service", err.Error())
+		resp.Diagnostics.AddError("Can not read service", errorDetail(err))
 		return
 	}
 
@@ -511,7 +526,7 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 	if len(request.Actions) > 0 {
 		actions, err := r.client.SetAutonomousActions(ctx, request)
 		if err != nil {
-			resp.Diagnostics.AddError("error creating skysql_autonomous resource", err.Error())
+			resp.Diagnostics.AddError("error creating skysql_autonomous resource", errorDetail(err))
 			return
 		}
 		resp.Diagnostics.Append(r.actionsResponseToData(actions, state)...)
@@ -527,6 +542,9 @@ func (r *AutonomousResource) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *AutonomousResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "skysql_autonomous.Delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data *AutonomousResourceModel
 
 	// Read Terraform plan data into the model
@@ -535,6 +553,8 @@ func (r *AutonomousResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(data.ServiceID.ValueString()))
+
 	if !data.AutoScaleDiskAction.IsUnknown() && !data.AutoScaleDiskAction.IsNull() {
 		resp.Diagnostics.Append(r.deleteAutoScaleDiskAction(ctx, data)...)
 		if resp.Diagnostics.HasError() {
@@ -567,7 +587,7 @@ func (r *AutonomousResource) deleteAutoScaleDiskAction(ctx context.Context, data
 	if !action.ID.IsNull() {
 		err := r.client.DeleteAutonomousAction(ctx, action.ID.ValueString())
 		if err != nil {
-			diags.AddError("error deleting skysql_autonomous resource", err.Error())
+			diags.AddError("error deleting skysql_autonomous resource", errorDetail(err))
 		}
 	}
 	return diags
@@ -583,7 +603,7 @@ func (r *AutonomousResource) deleteAutoScaleNodesVerticalAction(ctx context.Cont
 	if !action.ID.IsNull() {
 		err := r.client.DeleteAutonomousAction(ctx, action.ID.ValueString())
 		if err != nil {
-			diags.AddError("error deleting skysql_autonomous resource", err.Error())
+			diags.AddError("error deleting skysql_autonomous resource", errorDetail(err))
 		}
 	}
 	return diags
@@ -599,7 +619,7 @@ func (r *AutonomousResource) deleteAutoScaleNodesHorizontalAction(ctx context.Co
 	if !action.ID.IsNull() {
 		err := r.client.DeleteAutonomousAction(ctx, action.ID.ValueString())
 		if err != nil {
-			diags.AddError("error deleting skysql_autonomous resource", err.Error())
+			diags.AddError("error deleting skysql_autonomous resource", errorDetail(err))
 		}
 	}
 	return diags
@@ -163,7 +163,7 @@ func deleteActionSuccessResponse(t *testing.T) func(w http.ResponseWriter, req *
 }
 
 func TestModifyAutonomousResource(t *testing.T) {
-	configureOnce.Reset()
+	configuredClients.Reset()
 
 	const serviceID = "dbdgf42002419"
 	const serviceName = "test-service"
@@ -265,7 +265,7 @@ func getAutonomousByServiceID(t *testing.T, serviceID string) func(w http.Respon
 		r.Equal(
 			fmt.Sprintf("%s %s", http.MethodGet, "/als/v1/actions"),
 			fmt.Sprintf("%s %s", req.Method, req.URL.Path))
-		r.Equal("service_id="+serviceID, req.URL.RawQuery)
+		r.Equal(serviceID, req.URL.Query().Get("service_id"))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode([]autonomous.ActionResponse{})
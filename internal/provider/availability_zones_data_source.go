@@ -20,7 +20,7 @@ func NewAvailabilityZonesDataSource() datasource.DataSource {
 
 // AvailabilityZonesDataSource defines the data source implementation.
 type AvailabilityZonesDataSource struct {
-	client *skysql.Client
+	client skysql.API
 }
 
 // AvailabilityZonesDataSourceModel describes the data source data model.
@@ -89,12 +89,12 @@ func (d *AvailabilityZonesDataSource) Configure(ctx context.Context, req datasou
 		return
 	}
 
-	client, ok := req.ProviderData.(*skysql.Client)
+	client, ok := req.ProviderData.(skysql.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -119,7 +119,7 @@ func (d *AvailabilityZonesDataSource) Read(ctx context.Context, req datasource.R
 		}
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read SkySQL availability zones", err.Error())
+		resp.Diagnostics.AddError("Unable to Read SkySQL availability zones", errorDetail(err))
 		return
 	}
 
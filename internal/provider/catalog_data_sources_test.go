@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+)
+
+func TestRegionsAndTopologiesDataSources(t *testing.T) {
+	server := skysqltest.NewServer(skysqltest.WithAccessToken("[token]"))
+	defer server.Close()
+	os.Setenv("TF_SKYSQL_API_ACCESS_TOKEN", "[token]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", server.URL)
+
+	configuredClients.Reset()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "skysql_regions" "gcp" {
+  cloud_provider = "gcp"
+}
+
+data "skysql_topologies" "transactional" {
+  service_type = "transactional"
+}
+
+output "replicated_topologies" {
+  value = join(",", [for topology in data.skysql_topologies.transactional.topologies : topology.name if topology.supports_replication])
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.skysql_regions.gcp", "regions.#", "2"),
+					resource.TestCheckResourceAttr("data.skysql_regions.gcp", "regions.0.name", "europe-west1"),
+					resource.TestCheckResourceAttr("data.skysql_regions.gcp", "regions.1.name", "us-central1"),
+					resource.TestCheckResourceAttr("data.skysql_topologies.transactional", "topologies.#", "3"),
+					resource.TestCheckResourceAttr("data.skysql_topologies.transactional", "topologies.0.name", "es-single"),
+					resource.TestCheckResourceAttr("data.skysql_topologies.transactional", "topologies.0.supports_nosql", "true"),
+					resource.TestCheckOutput("replicated_topologies", "es-replica,xpand"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+)
+
+// clientConfig is the configuration of a SkySQL client, resolved from the provider block and the environment.
+type clientConfig struct {
+	BaseURL         string
+	AccessToken     string
+	TokenCommand    []string
+	MaxRetries      int
+	MaxRetryWait    time.Duration
+	CatalogCacheTTL time.Duration
+	Transport       skysql.TransportConfig
+	UserAgent       string
+
+	// transport is built from Transport by the provider, which reports its errors
+	transport http.RoundTripper
+}
+
+// key identifies the configuration without exposing the access token or the arguments of the token command.
+func (c clientConfig) key() string {
+	token := sha256.Sum256([]byte(c.AccessToken))
+	command := sha256.Sum256([]byte(strings.Join(c.TokenCommand, "\x00")))
+	return fmt.Sprintf("%s|%s|%s|%d|%s|%s|%+v|%s", c.BaseURL, hex.EncodeToString(token[:]), hex.EncodeToString(command[:]),
+		c.MaxRetries, c.MaxRetryWait, c.CatalogCacheTTL, c.Transport, c.UserAgent)
+}
+
+func (c clientConfig) newClient() *skysql.Client {
+	options := []skysql.Option{
+		skysql.WithRetryPolicy(c.MaxRetries, c.MaxRetryWait),
+		skysql.WithCatalogCache(c.CatalogCacheTTL),
+	}
+	if c.UserAgent != "" {
+		options = append(options, skysql.WithUserAgent(c.UserAgent))
+	}
+	if c.transport != nil {
+		options = append(options, skysql.WithTransport(c.transport))
+	}
+	// The token command takes precedence over the access token
+	if len(c.TokenCommand) > 0 {
+		options = append(options, skysql.WithTokenSource(commandTokenSource(c.TokenCommand)))
+	}
+	return skysql.New(c.BaseURL, c.AccessToken, options...)
+}
+
+// clientRegistry holds the clients whose access was validated, keyed by their configuration.
+// Terraform configures a provider many times during a run, so each configuration is validated
+// only once, while aliased providers with another base URL or token are validated on their own.
+// A failed validation is not stored, so it is repeated by the next provider that uses the configuration.
+type clientRegistry struct {
+	mu      sync.Mutex
+	clients map[string]*skysql.Client
+}
+
+var configuredClients = newClientRegistry()
+
+func newClientRegistry() *clientRegistry {
+	return &clientRegistry{clients: make(map[string]*skysql.Client)}
+}
+
+// client returns the validated client for the configuration, creating and validating it when needed.
+func (r *clientRegistry) client(ctx context.Context, config clientConfig) (*skysql.Client, error) {
+	key := config.key()
+
+	r.mu.Lock()
+	client, ok := r.clients[key]
+	r.mu.Unlock()
+	if ok {
+		return client, nil
+	}
+
+	client = config.newClient()
+	if err := validateClient(ctx, client); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Keep the client stored by a concurrent configuration, so they share the catalog cache
+	if stored, ok := r.clients[key]; ok {
+		return stored, nil
+	}
+	r.clients[key] = client
+	return client, nil
+}
+
+// Reset forgets the validated clients.
+func (r *clientRegistry) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients = make(map[string]*skysql.Client)
+}
+
+// validateClient checks that the API can be reached with the access token of the client.
+func validateClient(ctx context.Context, client *skysql.Client) error {
+	_, err := client.GetVersions(ctx, skysql.WithMaxItems(1))
+	return err
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRegistry(t *testing.T) {
+	ctx := context.Background()
+	staging := skysqltest.NewServer(skysqltest.WithAccessToken("[staging]"))
+	defer staging.Close()
+	production := skysqltest.NewServer(skysqltest.WithAccessToken("[production]"))
+	defer production.Close()
+	registry := newClientRegistry()
+
+	stagingClient, err := registry.client(ctx, clientConfig{BaseURL: staging.URL, AccessToken: "[staging]"})
+	require.NoError(t, err)
+	productionClient, err := registry.client(ctx, clientConfig{BaseURL: production.URL, AccessToken: "[production]"})
+	require.NoError(t, err)
+	require.NotSame(t, stagingClient, productionClient)
+
+	client, err := registry.client(ctx, clientConfig{BaseURL: staging.URL, AccessToken: "[staging]"})
+	require.NoError(t, err)
+	require.Same(t, stagingClient, client)
+	require.Len(t, staging.Requests(), 1, "a configuration is validated once")
+	require.Len(t, production.Requests(), 1)
+
+	for i := 0; i < 2; i++ {
+		_, err = registry.client(ctx, clientConfig{BaseURL: production.URL, AccessToken: "[staging]"})
+		require.ErrorIs(t, err, skysql.ErrorUnauthorized)
+	}
+	require.Len(t, production.Requests(), 3, "a failed validation is repeated")
+
+	registry.Reset()
+	client, err = registry.client(ctx, clientConfig{BaseURL: staging.URL, AccessToken: "[staging]"})
+	require.NoError(t, err)
+	require.NotSame(t, stagingClient, client)
+}
+
+func TestClientConfigKey(t *testing.T) {
+	config := clientConfig{BaseURL: "https://api.mariadb.com", AccessToken: "secret-token"}
+	require.NotContains(t, config.key(), "secret-token")
+
+	other := config
+	other.AccessToken = "other-token"
+	require.NotEqual(t, config.key(), other.key())
+
+	other = config
+	other.UserAgent = "terraform-provider-skysql/1.2.0 terraform/1.4.6 go/1.20.3"
+	require.NotEqual(t, config.key(), other.key())
+}
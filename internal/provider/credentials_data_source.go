@@ -18,7 +18,7 @@ func NewCredentialsDataSource() datasource.DataSource {
 
 // CredentialsDataSource defines the data source implementation.
 type CredentialsDataSource struct {
-	client *skysql.Client
+	client skysql.API
 }
 
 type CredentialsDataSourceDataSourceModel struct {
@@ -63,12 +63,12 @@ func (d *CredentialsDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*skysql.Client)
+	client, ok := req.ProviderData.(skysql.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -97,7 +97,7 @@ func (d *CredentialsDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	credentials, err := d.client.GetServiceCredentialsByID(ctx, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read SkySQL service", err.Error())
+		resp.Diagnostics.AddError("Unable to Read SkySQL service", errorDetail(err))
 		return
 	}
 
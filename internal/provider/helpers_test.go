@@ -1,6 +1,9 @@
 package provider
 
 import (
+	"context"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
 	"github.com/thanhpk/randstr"
 	"strings"
 	"testing"
@@ -32,3 +35,20 @@ GENERATE:
 
 	return strings.ToLower(string(runes))
 }
+
+// fakeSkySQLAPI is an in-memory skysql.API for unit tests.
+// Calling a method that is not overridden panics, because the embedded interface is nil.
+type fakeSkySQLAPI struct {
+	skysql.API
+	services []provisioning.Service
+}
+
+func (f *fakeSkySQLAPI) FindServicesByName(ctx context.Context, name string, projectID string) ([]provisioning.Service, error) {
+	services := make([]provisioning.Service, 0)
+	for _, service := range f.services {
+		if service.Name == name && (projectID == "" || service.ProjectID == projectID) {
+			services = append(services, service)
+		}
+	}
+	return services, nil
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// defaultProfile is the profile used when none is selected with the profile attribute or SKYSQL_PROFILE.
+const defaultProfile = "default"
+
+// credentialsProfile is a named profile of the credentials file.
+type credentialsProfile struct {
+	AccessToken string
+	BaseURL     string
+	ProjectID   string
+}
+
+// defaultCredentialsFile returns the path of the credentials file, ~/.skysql/credentials.
+func defaultCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".skysql", "credentials")
+}
+
+// expandHome replaces a leading ~ of the path with the home directory of the user.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// loadProfile reads the named profile from the credentials file.
+// When the profile was not selected explicitly, a missing file or profile is not an error and nil is returned.
+func loadProfile(path, name string, explicit bool) (*credentialsProfile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	profiles, err := parseCredentials(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		if !explicit {
+			return nil, nil
+		}
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("the profile %q was not found in %s. Available profiles: %s", name, path, strings.Join(names, ", "))
+	}
+	return &profile, nil
+}
+
+// parseCredentials parses the profiles of a credentials file. The file has one section per profile:
+//
+//	[default]
+//	access_token = my-access-token
+//
+//	[staging]
+//	access_token = my-staging-access-token
+//	base_url     = https://staging.api.mariadb.com
+//	project_id   = my-project-id
+//
+// Lines starting with # or ; are comments.
+func parseCredentials(r io.Reader) (map[string]credentialsProfile, error) {
+	profiles := make(map[string]credentialsProfile)
+	var section string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") {
+				return nil, fmt.Errorf("line %d: invalid profile header %q", line, text)
+			}
+			section = strings.TrimSpace(text[1 : len(text)-1])
+			if section == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", line)
+			}
+			if _, ok := profiles[section]; ok {
+				return nil, fmt.Errorf("line %d: duplicate profile %q", line, section)
+			}
+			profiles[section] = credentialsProfile{}
+			continue
+		}
+
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected a key = value pair", line)
+		}
+		if section == "" {
+			return nil, fmt.Errorf("line %d: the key is not in a profile section", line)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		profile := profiles[section]
+		switch key {
+		case "access_token":
+			profile.AccessToken = value
+		case "base_url":
+			profile.BaseURL = value
+		case "project_id":
+			profile.ProjectID = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q, valid keys are: access_token, base_url, project_id", line, key)
+		}
+		profiles[section] = profile
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
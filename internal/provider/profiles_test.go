@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCredentials(t *testing.T) {
+	profiles, err := parseCredentials(strings.NewReader(`
+# Production organization
+[default]
+access_token = production-token
+
+; Staging organization
+[ staging ]
+access_token=staging-token
+base_url     = https://staging.api.mariadb.com
+project_id   = 2f7b7a5c-1b1d-4b4e-9f3c-0c5b0c7d7e8f
+`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]credentialsProfile{
+		"default": {AccessToken: "production-token"},
+		"staging": {
+			AccessToken: "staging-token",
+			BaseURL:     "https://staging.api.mariadb.com",
+			ProjectID:   "2f7b7a5c-1b1d-4b4e-9f3c-0c5b0c7d7e8f",
+		},
+	}, profiles)
+
+	for name, tc := range map[string]struct {
+		content string
+		err     string
+	}{
+		"key outside a profile": {"access_token = token", "line 1: the key is not in a profile section"},
+		"unknown key":           {"[default]\ntoken = token", `line 2: unknown key "token"`},
+		"missing value":         {"[default]\naccess_token", "line 2: expected a key = value pair"},
+		"unclosed header":       {"[default", `line 1: invalid profile header "[default"`},
+		"empty profile name":    {"[ ]", "line 1: empty profile name"},
+		"duplicate profile":     {"[default]\n[default]", `line 2: duplicate profile "default"`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseCredentials(strings.NewReader(tc.content))
+			require.ErrorContains(t, err, tc.err)
+			require.NotContains(t, err.Error(), "token = token", "the error must not expose the token")
+		})
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials")
+	require.NoError(t, os.WriteFile(path, []byte("[default]\naccess_token = default-token\n\n[staging]\naccess_token = staging-token\n"), 0o600))
+
+	profile, err := loadProfile(path, "staging", true)
+	require.NoError(t, err)
+	require.Equal(t, &credentialsProfile{AccessToken: "staging-token"}, profile)
+
+	_, err = loadProfile(path, "production", true)
+	require.EqualError(t, err, `the profile "production" was not found in `+path+`. Available profiles: default, staging`)
+
+	profile, err = loadProfile(path, "production", false)
+	require.NoError(t, err)
+	require.Nil(t, profile, "the default profile is optional")
+
+	missing := filepath.Join(dir, "missing")
+	profile, err = loadProfile(missing, defaultProfile, false)
+	require.NoError(t, err)
+	require.Nil(t, profile, "the credentials file is optional")
+
+	_, err = loadProfile(missing, "staging", true)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFirstNonEmpty(t *testing.T) {
+	require.Equal(t, "env", firstNonEmpty("", "env", "profile"))
+	require.Equal(t, "", firstNonEmpty("", ""))
+}
+
+func TestConfigureProfilePrecedence(t *testing.T) {
+	var authorization string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		authorization = req.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]provisioning.Version{})
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"[default]\naccess_token = default-token\nbase_url = "+ts.URL+"\n\n"+
+			"[staging]\naccess_token = staging-token\nbase_url = "+ts.URL+"\n"), 0o600))
+	t.Setenv("SKYSQL_CREDENTIALS_FILE", path)
+	t.Setenv("TF_SKYSQL_API_ACCESS_TOKEN", "env-token")
+	t.Setenv("TF_SKYSQL_API_BASE_URL", "")
+
+	for name, tc := range map[string]struct {
+		profile       string
+		profileEnv    string
+		expectedToken string
+	}{
+		"the profile attribute wins over the environment": {profile: "staging", expectedToken: "staging-token"},
+		"SKYSQL_PROFILE wins over the environment":        {profileEnv: "staging", expectedToken: "staging-token"},
+		"the environment wins over the default profile":   {expectedToken: "env-token"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			configuredClients.Reset()
+			t.Setenv("SKYSQL_PROFILE", tc.profileEnv)
+
+			ctx := context.Background()
+			p := New("test")()
+			schemaResp := &provider.SchemaResponse{}
+			p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+			configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			values := make(map[string]tftypes.Value, len(configType.AttributeTypes))
+			for name, attributeType := range configType.AttributeTypes {
+				values[name] = tftypes.NewValue(attributeType, nil)
+			}
+			if tc.profile != "" {
+				values["profile"] = tftypes.NewValue(tftypes.String, tc.profile)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, values)}
+
+			resp := &provider.ConfigureResponse{}
+			p.Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			require.Equal(t, "Bearer "+tc.expectedToken, authorization)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ProjectDataSource{}
+
+func NewProjectDataSource() datasource.DataSource {
+	return &ProjectDataSource{}
+}
+
+// ProjectDataSource defines the data source implementation.
+type ProjectDataSource struct {
+	client skysql.API
+}
+
+// ProjectDataSourceModel describes the data source data model.
+type ProjectDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	Description types.String `tfsdk:"description"`
+	IsDefault   types.Bool   `tfsdk:"is_default"`
+}
+
+func (d *ProjectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (d *ProjectDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieve a single project by its ID, name or default flag. The lookup fails unless exactly one project matches.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the project.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the project.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "A regular expression the name of the project must match.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "The description of the project.",
+			},
+			"is_default": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the project is the default project. Set it to true to look up the default project.",
+			},
+		},
+	}
+}
+
+func (d *ProjectDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(skysql.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ProjectDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, filterDiags := newProjectFilter(state.Name, state.NameRegex, state.IsDefault)
+	resp.Diagnostics.Append(filterDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, err := d.client.GetProjects(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read SkySQL projects", errorDetail(err))
+		return
+	}
+
+	project, diags := selectProject(projects, state.Id, filter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Id = types.StringValue(project.Id)
+	state.Name = types.StringValue(project.Name)
+	state.Description = types.StringValue(project.Description)
+	state.IsDefault = types.BoolValue(project.IsDefault)
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// selectProject returns the only project that has the ID, when set, and matches the filter.
+func selectProject(projects []organization.Project, id types.String, filter *projectFilter) (*organization.Project, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	matches := make([]*organization.Project, 0)
+	for i := range projects {
+		if !id.IsNull() && id.ValueString() != projects[i].Id {
+			continue
+		}
+		if filter.matches(&projects[i]) {
+			matches = append(matches, &projects[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		diags.AddError("SkySQL project not found", "No project matches the given id, name, name_regex and is_default.")
+		return nil, diags
+	case 1:
+		return matches[0], diags
+	default:
+		names := make([]string, len(matches))
+		for i := range matches {
+			names[i] = fmt.Sprintf("%s (%s)", matches[i].Name, matches[i].Id)
+		}
+		diags.AddError(
+			"Multiple SkySQL projects found",
+			fmt.Sprintf("%d projects match: %s. Narrow the lookup with id, name or is_default.",
+				len(matches), strings.Join(names, ", ")))
+		return nil, diags
+	}
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectProject(t *testing.T) {
+	projects := []organization.Project{
+		{Id: "project-1", Name: "Default", IsDefault: true},
+		{Id: "project-2", Name: "payments"},
+		{Id: "project-3", Name: "payments-staging"},
+	}
+
+	tests := []struct {
+		name       string
+		id         types.String
+		filterName types.String
+		nameRegex  types.String
+		isDefault  types.Bool
+		expectedID string
+		errSummary string
+	}{
+		{name: "default project", isDefault: types.BoolValue(true), expectedID: "project-1"},
+		{name: "project by name", filterName: types.StringValue("payments"), expectedID: "project-2"},
+		{name: "project by id", id: types.StringValue("project-3"), expectedID: "project-3"},
+		{name: "ambiguous regex", nameRegex: types.StringValue("^payments"), errSummary: "Multiple SkySQL projects found"},
+		{name: "ambiguous without filters", errSummary: "Multiple SkySQL projects found"},
+		{name: "unknown name", filterName: types.StringValue("orders"), errSummary: "SkySQL project not found"},
+		{name: "invalid regex", nameRegex: types.StringValue("("), errSummary: "Invalid regular expression"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The zero values of the unset fields are null values
+			filter, diags := newProjectFilter(test.filterName, test.nameRegex, test.isDefault)
+			if !diags.HasError() {
+				var project *organization.Project
+				project, diags = selectProject(projects, test.id, filter)
+				if !diags.HasError() {
+					require.Empty(t, test.errSummary)
+					require.Equal(t, test.expectedID, project.Id)
+					return
+				}
+			}
+			require.Equal(t, test.errSummary, diags.Errors()[0].Summary())
+		})
+	}
+}
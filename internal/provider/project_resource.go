@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ProjectResource{}
+var _ resource.ResourceWithImportState = &ProjectResource{}
+var _ resource.ResourceWithConfigure = &ProjectResource{}
+
+func NewProjectResource() resource.Resource {
+	return &ProjectResource{}
+}
+
+// ProjectResource defines the resource implementation.
+type ProjectResource struct {
+	client skysql.API
+}
+
+// ProjectResourceModel describes the resource data model.
+type ProjectResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	IsDefault   types.Bool   `tfsdk:"is_default"`
+}
+
+func (r *ProjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a project. Project is a way of grouping the services.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the project.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the project.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "The description of the project.",
+			},
+			"is_default": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the project is the default project. The default project is used when a service is created without a project.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ProjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(skysql.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ProjectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := r.client.CreateProject(ctx, &organization.CreateProjectRequest{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		IsDefault:   data.IsDefault.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating project", errorDetail(err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a project", map[string]interface{}{
+		"id": project.Id,
+	})
+
+	data.fromProject(project)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ProjectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, err := r.client.GetProjects(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not read project", errorDetail(err))
+		return
+	}
+
+	for i := range projects {
+		if projects[i].Id == data.ID.ValueString() {
+			data.fromProject(&projects[i])
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	tflog.Warn(ctx, "SkySQL project not found, removing from state", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *ProjectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := r.client.UpdateProject(ctx, plan.ID.ValueString(), &organization.UpdateProjectRequest{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		IsDefault:   plan.IsDefault.ValueBool(),
+	})
+	if err != nil {
+		if errors.Is(err, skysql.ErrorNotFound) {
+			tflog.Warn(ctx, "SkySQL project not found, removing from state", map[string]interface{}{
+				"id": plan.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+		resp.Diagnostics.AddError("Error updating project", errorDetail(err))
+		return
+	}
+
+	plan.fromProject(project)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ProjectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	services, err := r.client.ListServices(ctx, func(values url.Values) {
+		values.Set("project_id", state.ID.ValueString())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting project", errorDetail(err))
+		return
+	}
+	names := projectServiceNames(services, state.ID.ValueString())
+	if len(names) > 0 {
+		resp.Diagnostics.AddError(
+			"Can not delete project",
+			fmt.Sprintf("The project still has %d services: %s. Delete or move them before deleting the project.",
+				len(names), strings.Join(names, ", ")))
+		return
+	}
+
+	err = r.client.DeleteProject(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, skysql.ErrorNotFound) {
+			tflog.Warn(ctx, "SkySQL project not found, removing from state", map[string]interface{}{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting project", errorDetail(err))
+	}
+}
+
+func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// projectServiceNames returns the names of the services of the project. The API may ignore the
+// project_id filter, so the services of other projects and the services without a project are skipped.
+func projectServiceNames(services []provisioning.Service, projectID string) []string {
+	names := make([]string, 0)
+	for _, service := range services {
+		if service.ProjectID == projectID {
+			names = append(names, service.Name)
+		}
+	}
+	return names
+}
+
+// fromProject sets the model from the project returned by the API.
+func (data *ProjectResourceModel) fromProject(project *organization.Project) {
+	data.ID = types.StringValue(project.Id)
+	data.Name = types.StringValue(project.Name)
+	data.Description = types.StringValue(project.Description)
+	data.IsDefault = types.BoolValue(project.IsDefault)
+}
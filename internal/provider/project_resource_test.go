@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectResource(t *testing.T) {
+	server := skysqltest.NewServer(skysqltest.WithAccessToken("[token]"))
+	defer server.Close()
+	os.Setenv("TF_SKYSQL_API_ACCESS_TOKEN", "[token]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", server.URL)
+
+	configuredClients.Reset()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		CheckDestroy: func(state *terraform.State) error {
+			for _, rs := range state.RootModule().Resources {
+				if rs.Type != "skysql_project" {
+					continue
+				}
+				for _, request := range server.Requests() {
+					if request.Method == "DELETE" && request.Path == "/organization/v1/projects/"+rs.Primary.ID {
+						return nil
+					}
+				}
+				return fmt.Errorf("project %s was not deleted", rs.Primary.ID)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "skysql_project" "default" {
+  name = "team-a"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("skysql_project.default", "id"),
+					resource.TestCheckResourceAttr("skysql_project.default", "name", "team-a"),
+					resource.TestCheckResourceAttr("skysql_project.default", "description", ""),
+					resource.TestCheckResourceAttr("skysql_project.default", "is_default", "false"),
+				),
+			},
+			{
+				Config: `
+resource "skysql_project" "default" {
+  name        = "team-b"
+  description = "Team B services"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_project.default", "name", "team-b"),
+					resource.TestCheckResourceAttr("skysql_project.default", "description", "Team B services"),
+				),
+			},
+			{
+				Config: `
+resource "skysql_project" "default" {
+  name        = "team-b"
+  description = "Team B services"
+  is_default  = true
+}
+`,
+				Check: resource.TestCheckResourceAttr("skysql_project.default", "is_default", "true"),
+			},
+			{
+				// The API value is kept when is_default is removed from the configuration
+				Config: `
+resource "skysql_project" "default" {
+  name        = "team-b"
+  description = "Team B services"
+}
+`,
+				Check: resource.TestCheckResourceAttr("skysql_project.default", "is_default", "true"),
+			},
+			{
+				ResourceName:      "skysql_project.default",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestProjectServiceNames(t *testing.T) {
+	services := []provisioning.Service{
+		{ID: "dbdgf42002418", Name: "orders-prod", ProjectID: "project-1"},
+		{ID: "dbdgf42002419", Name: "orders-stage", ProjectID: "project-2"},
+		{ID: "dbdgf42002420", Name: "orders-dev"},
+	}
+
+	require.Equal(t, []string{"orders-prod"}, projectServiceNames(services, "project-1"))
+	require.Empty(t, projectServiceNames(services, "project-3"))
+}
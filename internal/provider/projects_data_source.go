@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"regexp"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -18,12 +22,15 @@ func NewProjectsDataSource() datasource.DataSource {
 
 // ProjectsDataSource defines the data source implementation.
 type ProjectsDataSource struct {
-	client *skysql.Client
+	client skysql.API
 }
 
 // ProjectsDataSourceDataSourceModel describes the data source data model.
 type ProjectsDataSourceDataSourceModel struct {
-	Projects []ProjectModel `tfsdk:"projects"`
+	Name      types.String   `tfsdk:"name"`
+	NameRegex types.String   `tfsdk:"name_regex"`
+	IsDefault types.Bool     `tfsdk:"is_default"`
+	Projects  []ProjectModel `tfsdk:"projects"`
 }
 
 type ProjectModel struct {
@@ -41,6 +48,18 @@ func (d *ProjectsDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 	resp.Schema = schema.Schema{
 		Description: "Retrieve the list of projects. Project is a way of grouping the services.",
 		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the project with this name.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the projects with a name that matches this regular expression.",
+			},
+			"is_default": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return the default project (true) or the other projects (false).",
+			},
 			"projects": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -74,12 +93,12 @@ func (d *ProjectsDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*skysql.Client)
+	client, ok := req.ProviderData.(skysql.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -98,13 +117,23 @@ func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
+	filter, filterDiags := newProjectFilter(state.Name, state.NameRegex, state.IsDefault)
+	resp.Diagnostics.Append(filterDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	projects, err := d.client.GetProjects(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read SkySQL projects", err.Error())
+		resp.Diagnostics.AddError("Unable to Read SkySQL projects", errorDetail(err))
 		return
 	}
 
+	state.Projects = make([]ProjectModel, 0)
 	for _, project := range projects {
+		if !filter.matches(&project) {
+			continue
+		}
 		projectState := ProjectModel{
 			Id:          types.StringValue(project.Id),
 			Name:        types.StringValue(project.Name),
@@ -121,3 +150,36 @@ func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 }
+
+// projectFilter selects the projects matching all the filters that are set.
+type projectFilter struct {
+	name      types.String
+	nameRegex *regexp.Regexp
+	isDefault types.Bool
+}
+
+func newProjectFilter(name types.String, nameRegex types.String, isDefault types.Bool) (*projectFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	filter := &projectFilter{name: name, isDefault: isDefault}
+	if !nameRegex.IsNull() {
+		var err error
+		filter.nameRegex, err = regexp.Compile(nameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+		}
+	}
+	return filter, diags
+}
+
+func (f *projectFilter) matches(project *organization.Project) bool {
+	if !f.name.IsNull() && f.name.ValueString() != project.Name {
+		return false
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(project.Name) {
+		return false
+	}
+	if !f.isDefault.IsNull() && f.isDefault.ValueBool() != project.IsDefault {
+		return false
+	}
+	return true
+}
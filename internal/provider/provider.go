@@ -3,22 +3,30 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
-	"github.com/matryer/resync"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure skySQLProvider satisfies various provider interfaces.
 var _ provider.Provider = &skySQLProvider{}
 
-var configureOnce resync.Once
-
 // skySQLProvider defines the provider implementation.
 type skySQLProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -29,8 +37,31 @@ type skySQLProvider struct {
 
 // SkySQLProviderModel describes the provider data model.
 type SkySQLProviderModel struct {
-	BaseURL     types.String `tfsdk:"base_url"`
-	AccessToken types.String `tfsdk:"access_token"`
+	BaseURL               types.String   `tfsdk:"base_url"`
+	AccessToken           types.String   `tfsdk:"access_token"`
+	MaxRetries            types.Int64    `tfsdk:"max_retries"`
+	MaxRetryWait          types.String   `tfsdk:"max_retry_wait"`
+	SkipCatalogValidation types.Bool     `tfsdk:"skip_catalog_validation"`
+	CatalogCacheTTL       types.String   `tfsdk:"catalog_cache_ttl"`
+	Profile               types.String   `tfsdk:"profile"`
+	CredentialsFile       types.String   `tfsdk:"credentials_file"`
+	TokenCommand          []types.String `tfsdk:"token_command"`
+	ProxyURL              types.String   `tfsdk:"proxy_url"`
+	CABundleFile          types.String   `tfsdk:"ca_bundle_file"`
+	ClientCertFile        types.String   `tfsdk:"client_cert_file"`
+	ClientKeyFile         types.String   `tfsdk:"client_key_file"`
+	InsecureSkipVerify    types.Bool     `tfsdk:"insecure_skip_verify"`
+	ConnectTimeout        types.String   `tfsdk:"connect_timeout"`
+	UserAgentSuffix       types.String   `tfsdk:"user_agent_suffix"`
+}
+
+// providerClient is the client shared with the resources and data sources,
+// along with the provider settings that change their behavior.
+type providerClient struct {
+	skysql.API
+	skipCatalogValidation bool
+	// defaultProjectID is the project of the selected profile, used when a service has no project_id
+	defaultProjectID string
 }
 
 func (p *skySQLProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -50,6 +81,87 @@ func (p *skySQLProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 			"base_url": schema.StringAttribute{
 				Optional: true,
 			},
+			"token_command": schema.ListAttribute{
+				MarkdownDescription: "Command and arguments of a program that prints a short-lived SkySQL API access token, " +
+					"as a JSON document with the `access_token` and its `expires_at` RFC3339 timestamp. " +
+					"The program is run again before the token expires and when the API rejects the token",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("access_token")),
+				},
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile of the credentials file to read the access token, base URL and default project from. " +
+					"Can also be set with the `SKYSQL_PROFILE` environment variable. The settings of a selected profile take precedence " +
+					"over the `TF_SKYSQL_API_*` environment variables. Default is `" + defaultProfile + "`",
+				Optional: true,
+			},
+			"credentials_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the credentials file. Can also be set with the `SKYSQL_CREDENTIALS_FILE` environment variable. " +
+					"Default is `~/.skysql/credentials`",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of retries of a failed SkySQL API request. Default is `%d`", skysql.DefaultRetryCount),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_retry_wait": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Maximum time to wait between two retries, e.g. `30s` or `1m`. Default is `%s`", skysql.DefaultRetryMaxWaitTime),
+				Optional:            true,
+			},
+			"catalog_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How long the catalog of versions, sizes, regions, zones and topologies is cached, e.g. `10m`. "+
+					"Set it to `0s` to turn the cache off. Default is `%s`", skysql.DefaultCatalogCacheTTL),
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy to connect to the SkySQL API through, e.g. `http://proxy.example.com:3128`. " +
+					"Default is the proxy of the `HTTPS_PROXY` and `NO_PROXY` environment variables",
+				Optional: true,
+			},
+			"ca_bundle_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a PEM file of certificate authorities to trust in addition to the system ones, " +
+					"e.g. the private CA of a TLS-intercepting proxy",
+				Optional: true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the PEM client certificate for mutual TLS. Requires `client_key_file`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_file")),
+				},
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the PEM private key of the client certificate for mutual TLS. Requires `client_cert_file`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_file")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the SkySQL API certificate. **Only use it for tests**, " +
+					"it makes the connection vulnerable to man-in-the-middle attacks. Default is `false`",
+				Optional: true,
+			},
+			"connect_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Maximum time to wait for a connection to the SkySQL API, e.g. `10s`. Default is `%s`", skysql.DefaultConnectTimeout),
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the User-Agent header of the SkySQL API requests, e.g. `deploy-pipeline/42`, " +
+					"so the requests of a pipeline can be told apart. Can also be set with the `TF_APPEND_USER_AGENT` environment variable",
+				Optional: true,
+			},
+			"skip_catalog_validation": schema.BoolAttribute{
+				MarkdownDescription: "Skip checking the service attributes against the SkySQL catalog of versions, sizes, regions, " +
+					"zones and topologies at plan time. Can also be set with the `TF_SKYSQL_SKIP_CATALOG_VALIDATION` environment variable. Default is `false`",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -63,10 +175,30 @@ func getEnv(key, fallback string) string {
 	return value
 }
 
-func (p *skySQLProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
-	accessToken := os.Getenv("TF_SKYSQL_API_ACCESS_TOKEN")
-	baseURL := getEnv("TF_SKYSQL_API_BASE_URL", "https://api.mariadb.com")
+// firstNonEmpty returns the first value that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
 
+// Configure resolves each setting from the first source that sets it, in this order:
+//
+//  1. the provider configuration block, e.g. access_token
+//  2. the profile of the credentials file selected by the profile attribute or SKYSQL_PROFILE
+//  3. the environment variables, e.g. TF_SKYSQL_API_ACCESS_TOKEN
+//  4. the default profile of the credentials file, when no profile is selected
+//  5. the built-in defaults, e.g. the base URL https://api.mariadb.com
+//
+// A selected profile wins over the environment variables, so a token exported in the shell
+// doesn't silently replace the token of the organization the configuration asks for.
+//
+// The token_command attribute is a setting of the provider block as well, so its tokens are used
+// instead of the access token of the environment variables or the profile.
+func (p *skySQLProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data SkySQLProviderModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -75,61 +207,172 @@ func (p *skySQLProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	// Check configuration data, which should take precedence over
-	// environment variable data, if found.
-	if data.AccessToken.ValueString() != "" {
-		accessToken = data.AccessToken.ValueString()
+	profileName := getEnv("SKYSQL_PROFILE", "")
+	if data.Profile.ValueString() != "" {
+		profileName = data.Profile.ValueString()
+	}
+	credentialsFile := getEnv("SKYSQL_CREDENTIALS_FILE", defaultCredentialsFile())
+	if data.CredentialsFile.ValueString() != "" {
+		credentialsFile = data.CredentialsFile.ValueString()
+	}
+
+	// A profile that was selected explicitly must exist, while the default profile is optional
+	profile, err := loadProfile(expandHome(credentialsFile), firstNonEmpty(profileName, defaultProfile), profileName != "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read the SkySQL credentials file",
+			"While configuring the provider, the profile could not be read: "+err.Error(),
+		)
+		return
+	}
+	if profile == nil {
+		profile = &credentialsProfile{}
+	}
+
+	selectedProfile, fallbackProfile := &credentialsProfile{}, profile
+	if profileName != "" {
+		selectedProfile, fallbackProfile = profile, &credentialsProfile{}
 	}
+	accessToken := firstNonEmpty(data.AccessToken.ValueString(), selectedProfile.AccessToken,
+		os.Getenv("TF_SKYSQL_API_ACCESS_TOKEN"), fallbackProfile.AccessToken)
+	baseURL := firstNonEmpty(data.BaseURL.ValueString(), selectedProfile.BaseURL,
+		os.Getenv("TF_SKYSQL_API_BASE_URL"), fallbackProfile.BaseURL, "https://api.mariadb.com")
 
-	if data.BaseURL.ValueString() != "" {
-		baseURL = data.BaseURL.ValueString()
+	tokenCommand := make([]string, 0, len(data.TokenCommand))
+	for _, arg := range data.TokenCommand {
+		tokenCommand = append(tokenCommand, arg.ValueString())
 	}
 
-	if accessToken == "" {
+	if accessToken == "" && len(tokenCommand) == 0 {
 		resp.Diagnostics.AddError(
 			"Missing SkySQL Access Token Configuration",
 			"While configuring the provider, the API access token was not found in "+
-				"the TF_SKYSQL_API_ACCESS_TOKEN environment variable or provider "+
-				"configuration block access_token attribute.",
+				"the TF_SKYSQL_API_ACCESS_TOKEN environment variable, provider "+
+				"configuration block access_token or token_command attribute or credentials file profile.",
 		)
 		// Not returning early allows the logic to collect all errors.
 	}
 
-	if baseURL == "" {
+	maxRetries := skysql.DefaultRetryCount
+	if !data.MaxRetries.IsNull() {
+		maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	maxRetryWait := skysql.DefaultRetryMaxWaitTime
+	if data.MaxRetryWait.ValueString() != "" {
+		var err error
+		maxRetryWait, err = time.ParseDuration(data.MaxRetryWait.ValueString())
+		if err != nil || maxRetryWait <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retry_wait"),
+				"Invalid max_retry_wait value",
+				fmt.Sprintf("The %q is not a valid positive duration, use a value like 30s or 1m", data.MaxRetryWait.ValueString()),
+			)
+		}
+	}
+
+	catalogCacheTTL := skysql.DefaultCatalogCacheTTL
+	if data.CatalogCacheTTL.ValueString() != "" {
+		var err error
+		catalogCacheTTL, err = time.ParseDuration(data.CatalogCacheTTL.ValueString())
+		if err != nil || catalogCacheTTL < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("catalog_cache_ttl"),
+				"Invalid catalog_cache_ttl value",
+				fmt.Sprintf("The %q is not a valid duration, use a value like 10m, or 0s to turn the cache off", data.CatalogCacheTTL.ValueString()),
+			)
+		}
+	}
+
+	connectTimeout := skysql.DefaultConnectTimeout
+	if data.ConnectTimeout.ValueString() != "" {
+		var err error
+		connectTimeout, err = time.ParseDuration(data.ConnectTimeout.ValueString())
+		if err != nil || connectTimeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("connect_timeout"),
+				"Invalid connect_timeout value",
+				fmt.Sprintf("The %q is not a valid positive duration, use a value like 10s or 1m", data.ConnectTimeout.ValueString()),
+			)
+		}
+	}
+
+	transportConfig := skysql.TransportConfig{
+		ProxyURL:           data.ProxyURL.ValueString(),
+		CABundleFile:       expandHome(data.CABundleFile.ValueString()),
+		ClientCertFile:     expandHome(data.ClientCertFile.ValueString()),
+		ClientKeyFile:      expandHome(data.ClientKeyFile.ValueString()),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+		ConnectTimeout:     connectTimeout,
+	}
+	transport, err := skysql.NewTransport(transportConfig)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Missing Endpoint Configuration",
-			"While configuring the provider, the endpoint was not found in "+
-				"the TF_SKYSQL_API_BASE_URL environment variable or provider "+
-				"configuration block base_url attribute.",
+			"Invalid SkySQL API connection settings",
+			"While configuring the provider, the proxy and TLS settings could not be applied: "+err.Error(),
+		)
+	}
+
+	if transportConfig.InsecureSkipVerify {
+		tflog.Warn(ctx, "The verification of the SkySQL API certificate is disabled")
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"Insecure connection to the SkySQL API",
+			"The verification of the SkySQL API certificate is disabled, so the access token and the service "+
+				"credentials can be intercepted by a man-in-the-middle attack. Only use insecure_skip_verify for tests.",
+		)
+	}
+
+	userAgentSuffix := firstNonEmpty(data.UserAgentSuffix.ValueString(), os.Getenv("TF_APPEND_USER_AGENT"))
+	if strings.IndexFunc(userAgentSuffix, unicode.IsControl) >= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_agent_suffix"),
+			"Invalid user_agent_suffix value",
+			"The User-Agent suffix must not contain control characters, like new lines.",
 		)
-		// Not returning early allows the logic to collect all errors.
 	}
 
-	client := skysql.New(baseURL, accessToken)
+	skipCatalogValidation, _ := strconv.ParseBool(os.Getenv("TF_SKYSQL_SKIP_CATALOG_VALIDATION"))
+	if !data.SkipCatalogValidation.IsNull() {
+		skipCatalogValidation = data.SkipCatalogValidation.ValueBool()
+	}
 
-	configureOnce.Do(func() {
-		_, err := client.GetVersions(ctx, skysql.WithPageSize(1))
-		if err != nil {
-			if errors.Is(err, skysql.ErrorUnauthorized) {
-				resp.Diagnostics.AddError(
-					"Unable to connect to SkySQL",
-					"While configuring the provider, the API access token was not valid.",
-				)
-				return
-			}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := configuredClients.client(ctx, clientConfig{
+		BaseURL:         baseURL,
+		AccessToken:     accessToken,
+		TokenCommand:    tokenCommand,
+		MaxRetries:      maxRetries,
+		MaxRetryWait:    maxRetryWait,
+		CatalogCacheTTL: catalogCacheTTL,
+		Transport:       transportConfig,
+		UserAgent:       skysql.UserAgent(p.version, req.TerraformVersion, userAgentSuffix),
+		transport:       transport,
+	})
+	if err != nil {
+		if errors.Is(err, skysql.ErrorUnauthorized) {
 			resp.Diagnostics.AddError(
 				"Unable to connect to SkySQL",
-				"While configuring the provider, the API returns error: "+err.Error(),
+				"While configuring the provider, the API access token was not valid.",
 			)
+			return
 		}
-	})
-
-	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Unable to connect to SkySQL",
+			"While configuring the provider, the API returns error: "+errorDetail(err),
+		)
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = &providerClient{
+		API:                   client,
+		skipCatalogValidation: skipCatalogValidation,
+		defaultProjectID:      profile.ProjectID,
+	}
+	resp.ResourceData = resp.DataSourceData
 }
 
 func (p *skySQLProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -137,16 +380,22 @@ func (p *skySQLProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewServiceResource,
 		NewServiceAllowListResource,
 		NewAutonomousResource,
+		NewProjectResource,
 	}
 }
 
 func (p *skySQLProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewProjectsDataSource,
+		NewProjectDataSource,
 		NewVersionsDataSource,
 		NewServiceDataSource,
+		NewServicesDataSource,
 		NewCredentialsDataSource,
 		NewAvailabilityZonesDataSource,
+		NewSizesDataSource,
+		NewRegionsDataSource,
+		NewTopologiesDataSource,
 	}
 }
 
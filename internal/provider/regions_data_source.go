@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &RegionsDataSource{}
+
+func NewRegionsDataSource() datasource.DataSource {
+	return &RegionsDataSource{}
+}
+
+// RegionsDataSource defines the data source implementation.
+type RegionsDataSource struct {
+	client skysql.API
+}
+
+// RegionsDataSourceModel describes the data source data model.
+type RegionsDataSourceModel struct {
+	Provider types.String  `tfsdk:"cloud_provider"`
+	Regions  []RegionModel `tfsdk:"regions"`
+}
+
+type RegionModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Provider    types.String `tfsdk:"cloud_provider"`
+}
+
+func (d *RegionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_regions"
+}
+
+func (d *RegionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieve the list of regions the services can be created in.",
+		Attributes: map[string]schema.Attribute{
+			"cloud_provider": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the regions of this cloud provider. Valid values are: aws or gcp",
+				Validators: []validator.String{
+					stringvalidator.OneOf("aws", "gcp"),
+				},
+			},
+			"regions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The list of regions, sorted by cloud provider and name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the region",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the region, e.g. us-central1. Use it as the region of a service",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The display name of the region",
+						},
+						"cloud_provider": schema.StringAttribute{
+							Computed:    true,
+							Description: "The cloud provider of the region",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RegionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(skysql.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RegionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state RegionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	regions, err := d.client.GetRegions(ctx, func(values url.Values) {
+		if !state.Provider.IsNull() {
+			values.Set("provider", state.Provider.ValueString())
+		}
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read SkySQL regions", errorDetail(err))
+		return
+	}
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		if regions[i].Provider != regions[j].Provider {
+			return regions[i].Provider < regions[j].Provider
+		}
+		return regions[i].Name < regions[j].Name
+	})
+
+	state.Regions = make([]RegionModel, 0, len(regions))
+	for _, region := range regions {
+		state.Regions = append(state.Regions, RegionModel{
+			ID:          types.StringValue(region.ID),
+			Name:        types.StringValue(region.Name),
+			DisplayName: types.StringValue(region.DisplayName),
+			Provider:    types.StringValue(region.Provider),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -3,10 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -18,12 +24,13 @@ func NewServiceDataSource() datasource.DataSource {
 
 // ServiceDataSource defines the data source implementation.
 type ServiceDataSource struct {
-	client *skysql.Client
+	client skysql.API
 }
 
 type ServiceDataSourceModel struct {
 	ID                 types.String                     `tfsdk:"service_id"`
 	Name               types.String                     `tfsdk:"name"`
+	ProjectID          types.String                     `tfsdk:"project_id"`
 	Region             types.String                     `tfsdk:"region"`
 	Provider           types.String                     `tfsdk:"cloud_provider"`
 	Tier               types.String                     `tfsdk:"tier"`
@@ -77,161 +84,193 @@ func (d *ServiceDataSource) Metadata(ctx context.Context, req datasource.Metadat
 func (d *ServiceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Returns an full SkySQL service details",
-		Attributes: map[string]schema.Attribute{
+		Attributes: serviceDataSourceAttributes(map[string]schema.Attribute{
 			"service_id": schema.StringAttribute{
-				Required:    true,
-				Description: "The ID of the service",
-			},
-			"name": schema.StringAttribute{
-				Computed:    true,
-				Description: "The name of the service",
-			},
-			"region": schema.StringAttribute{
-				Computed:    true,
-				Description: "The region where the service is deployed",
-			},
-			"cloud_provider": schema.StringAttribute{
-				Computed:    true,
-				Description: "The cloud provider where the service is deployed",
-			},
-			"tier": schema.StringAttribute{
-				Computed:    true,
-				Description: "The tier of the service. Possible values are: foundation or power",
-			},
-			"topology": schema.StringAttribute{
-				Computed:    true,
-				Description: "The topology of the service. Possible values are: es-single, es-replica, xpand, csdw and sa",
-			},
-			"version": schema.StringAttribute{
-				Computed:    true,
-				Description: "The database service version.",
-			},
-			"architecture": schema.StringAttribute{
-				Computed:    true,
-				Description: "The CPU architecture of the service. Possible values are: amd64 or arm64",
-			},
-			"size": schema.StringAttribute{
-				Computed:    true,
-				Description: "The size of the service. Possible values are: sky-2x4, sky-2x8 etc",
-			},
-			"nodes": schema.Int64Attribute{
-				Computed:    true,
-				Description: "The number of nodes in the service.",
-			},
-			"ssl_enabled": schema.BoolAttribute{
-				Computed:    true,
-				Description: "Indicates whether SSL is enabled for the service.",
-			},
-			"nosql_enabled": schema.BoolAttribute{
-				Computed:    true,
-				Description: "Indicates whether NoSQL is enabled for the service.",
-			},
-			"fqdn": schema.StringAttribute{
-				Computed:    true,
-				Description: "The fully qualified domain name of the service.",
-			},
-			"status": schema.StringAttribute{
-				Computed:    true,
-				Description: "The service status",
-			},
-			"created_on": schema.Int64Attribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "The timestamp when the service was created.",
-			},
-			"updated_on": schema.Int64Attribute{
-				Computed:    true,
-				Description: "The timestamp when the service was last updated.",
+				Description: "The ID of the service. Exactly one of service_id or name must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
 			},
-			"created_by": schema.StringAttribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "The user who created the service.",
+				Description: "The name of the service. Exactly one of service_id or name must be set.",
 			},
-			"updated_by": schema.StringAttribute{
+			"project_id": schema.StringAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "The user who last updated the service.",
+				Description: "The ID of the project of the service. When set together with name, only the services of this project are searched.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("service_id")),
+				},
 			},
-			"endpoints": schema.ListNestedAttribute{
-				Computed:    true,
-				Description: "The list of endpoints for the service. Each endpoint has a name and a list of ports. ",
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							Computed: true,
-						},
-						"ports": schema.ListNestedAttribute{
-							Computed: true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"name": schema.StringAttribute{
-										Computed: true,
-									},
-									"port": schema.Int64Attribute{
-										Computed: true,
-									},
-									"purpose": schema.StringAttribute{
-										Computed: true,
-									},
+		}),
+	}
+}
+
+// serviceDataSourceAttributes adds the computed attributes of a service that are not already in the given attributes.
+func serviceDataSourceAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	for name, attribute := range map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the service",
+		},
+		"project_id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the project of the service",
+		},
+		"region": schema.StringAttribute{
+			Computed:    true,
+			Description: "The region where the service is deployed",
+		},
+		"cloud_provider": schema.StringAttribute{
+			Computed:    true,
+			Description: "The cloud provider where the service is deployed",
+		},
+		"tier": schema.StringAttribute{
+			Computed:    true,
+			Description: "The tier of the service. Possible values are: foundation or power",
+		},
+		"topology": schema.StringAttribute{
+			Computed:    true,
+			Description: "The topology of the service. Possible values are: es-single, es-replica, xpand, csdw and sa",
+		},
+		"version": schema.StringAttribute{
+			Computed:    true,
+			Description: "The database service version.",
+		},
+		"architecture": schema.StringAttribute{
+			Computed:    true,
+			Description: "The CPU architecture of the service. Possible values are: amd64 or arm64",
+		},
+		"size": schema.StringAttribute{
+			Computed:    true,
+			Description: "The size of the service. Possible values are: sky-2x4, sky-2x8 etc",
+		},
+		"nodes": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The number of nodes in the service.",
+		},
+		"ssl_enabled": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates whether SSL is enabled for the service.",
+		},
+		"nosql_enabled": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates whether NoSQL is enabled for the service.",
+		},
+		"fqdn": schema.StringAttribute{
+			Computed:    true,
+			Description: "The fully qualified domain name of the service.",
+		},
+		"status": schema.StringAttribute{
+			Computed:    true,
+			Description: "The service status",
+		},
+		"created_on": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The timestamp when the service was created.",
+		},
+		"updated_on": schema.Int64Attribute{
+			Computed:    true,
+			Description: "The timestamp when the service was last updated.",
+		},
+		"created_by": schema.StringAttribute{
+			Computed:    true,
+			Description: "The user who created the service.",
+		},
+		"updated_by": schema.StringAttribute{
+			Computed:    true,
+			Description: "The user who last updated the service.",
+		},
+		"endpoints": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The list of endpoints for the service. Each endpoint has a name and a list of ports. ",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed: true,
+					},
+					"ports": schema.ListNestedAttribute{
+						Computed: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Computed: true,
+								},
+								"port": schema.Int64Attribute{
+									Computed: true,
+								},
+								"purpose": schema.StringAttribute{
+									Computed: true,
 								},
 							},
 						},
-						"mechanism": schema.StringAttribute{
-							Computed: true,
-						},
-						"visibility": schema.StringAttribute{
-							Computed: true,
-						},
-						"endpoint_service": schema.StringAttribute{
-							Computed: true,
-						},
-						"allowed_accounts": schema.ListAttribute{
-							Computed:    true,
-							ElementType: types.StringType,
-						},
 					},
-				},
-			},
-			"storage_volume": schema.SingleNestedAttribute{
-				Computed:    true,
-				Description: "The storage volume for the service.",
-				Attributes: map[string]schema.Attribute{
-					"size": schema.Int64Attribute{
-						Computed:    true,
-						Description: "The size of the storage volume in GB.",
+					"mechanism": schema.StringAttribute{
+						Computed: true,
 					},
-					"volume_type": schema.StringAttribute{
-						Computed:    true,
-						Description: "The type of the storage volume. Possible values are: gp2, io1 etc",
+					"visibility": schema.StringAttribute{
+						Computed: true,
+					},
+					"endpoint_service": schema.StringAttribute{
+						Computed: true,
 					},
-					"iops": schema.Int64Attribute{
+					"allowed_accounts": schema.ListAttribute{
 						Computed:    true,
-						Optional:    true,
-						Description: "The number of IOPS for the storage volume. This is only applicable for io1 volumes.",
+						ElementType: types.StringType,
 					},
 				},
 			},
-			"outbound_ips": schema.ListAttribute{
-				Computed:    true,
-				ElementType: types.StringType,
-				Description: "The list of outbound IP addresses for the service.",
-			},
-			"is_active": schema.BoolAttribute{
-				Computed:    true,
-				Description: "Indicates whether the service is active.",
-			},
-			"service_type": schema.StringAttribute{
-				Computed:    true,
-				Description: "The service type. Possible values: analytical or transactional",
-			},
-			"replication_enabled": schema.BoolAttribute{
-				Computed:    true,
-				Description: "Indicates whether replication is enabled for the service.",
-			},
-			"primary_host": schema.StringAttribute{
-				Computed:    true,
-				Description: "The primary host for the service. This is only applicable for replication enabled services.",
+		},
+		"storage_volume": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The storage volume for the service.",
+			Attributes: map[string]schema.Attribute{
+				"size": schema.Int64Attribute{
+					Computed:    true,
+					Description: "The size of the storage volume in GB.",
+				},
+				"volume_type": schema.StringAttribute{
+					Computed:    true,
+					Description: "The type of the storage volume. Possible values are: gp2, io1 etc",
+				},
+				"iops": schema.Int64Attribute{
+					Computed:    true,
+					Optional:    true,
+					Description: "The number of IOPS for the storage volume. This is only applicable for io1 volumes.",
+				},
 			},
 		},
+		"outbound_ips": schema.ListAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The list of outbound IP addresses for the service.",
+		},
+		"is_active": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates whether the service is active.",
+		},
+		"service_type": schema.StringAttribute{
+			Computed:    true,
+			Description: "The service type. Possible values: analytical or transactional",
+		},
+		"replication_enabled": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Indicates whether replication is enabled for the service.",
+		},
+		"primary_host": schema.StringAttribute{
+			Computed:    true,
+			Description: "The primary host for the service. This is only applicable for replication enabled services.",
+		},
+	} {
+		if _, ok := attributes[name]; !ok {
+			attributes[name] = attribute
+		}
 	}
+	return attributes
 }
 
 func (d *ServiceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -240,12 +279,12 @@ func (d *ServiceDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*skysql.Client)
+	client, ok := req.ProviderData.(skysql.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -264,22 +303,72 @@ func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	if data.ID.ValueString() == "" {
-		resp.Diagnostics.AddError(
-			"Missing Service ID",
-			"service_id is required",
-		)
+	var service *provisioning.Service
+	if data.ID.ValueString() != "" {
+		var err error
+		service, err = d.client.GetServiceByID(ctx, data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read SkySQL service", errorDetail(err))
+			return
+		}
+	} else {
+		service = d.findServiceByName(ctx, data.Name.ValueString(), data.ProjectID.ValueString(), resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.fromService(service)
+
+	// Set state
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+}
 
-	service, err := d.client.GetServiceByID(ctx, data.ID.ValueString())
+// findServiceByName returns the only service with the given name, and adds an error when there is none or several.
+func (d *ServiceDataSource) findServiceByName(ctx context.Context, name string, projectID string, resp *datasource.ReadResponse) *provisioning.Service {
+	services, err := d.client.FindServicesByName(ctx, name, projectID)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read SkySQL service", err.Error())
-		return
+		resp.Diagnostics.AddError("Unable to Read SkySQL service", errorDetail(err))
+		return nil
+	}
+
+	scope := ""
+	if projectID != "" {
+		scope = fmt.Sprintf(" in project %s", projectID)
+	}
+
+	switch len(services) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"SkySQL service not found",
+			fmt.Sprintf("No service named %q was found%s.", name, scope))
+		return nil
+	case 1:
+		return &services[0]
+	default:
+		ids := make([]string, len(services))
+		for i := range services {
+			ids[i] = services[i].ID
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Multiple SkySQL services found",
+			fmt.Sprintf("%d services named %q were found%s: %s. Set project_id or service_id to select one.",
+				len(services), name, scope, strings.Join(ids, ", ")))
+		return nil
 	}
+}
 
+// fromService sets the model from the service returned by the API.
+func (data *ServiceDataSourceModel) fromService(service *provisioning.Service) {
 	data.ID = types.StringValue(service.ID)
 	data.Name = types.StringValue(service.Name)
+	data.ProjectID = types.StringValue(service.ProjectID)
 	data.Region = types.StringValue(service.Region)
 	data.Provider = types.StringValue(service.Provider)
 	data.Tier = types.StringValue(service.Tier)
@@ -329,10 +418,4 @@ func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	data.ServiceType = types.StringValue(service.ServiceType)
 	data.ReplicationEnabled = types.BoolValue(service.ReplicationEnabled)
 	data.PrimaryHost = types.StringValue(service.PrimaryHost)
-	// Set state
-	diags := resp.State.Set(ctx, &data)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
 }
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceDataSourceFindServiceByName(t *testing.T) {
+	d := &ServiceDataSource{
+		client: &fakeSkySQLAPI{
+			services: []provisioning.Service{
+				{ID: "db00000001", Name: "orders-prod", ProjectID: "project-1"},
+				{ID: "db00000002", Name: "orders-prod", ProjectID: "project-2"},
+				{ID: "db00000003", Name: "billing-prod", ProjectID: "project-1"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		service    string
+		projectID  string
+		expectedID string
+		errSummary string
+	}{
+		{name: "unique name", service: "billing-prod", expectedID: "db00000003"},
+		{name: "name in project", service: "orders-prod", projectID: "project-2", expectedID: "db00000002"},
+		{name: "ambiguous name", service: "orders-prod", errSummary: "Multiple SkySQL services found"},
+		{name: "unknown name", service: "orders-dev", errSummary: "SkySQL service not found"},
+		{name: "name in another project", service: "billing-prod", projectID: "project-2", errSummary: "SkySQL service not found"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &datasource.ReadResponse{}
+			service := d.findServiceByName(context.Background(), test.service, test.projectID, resp)
+			if test.errSummary != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				require.Equal(t, test.errSummary, resp.Diagnostics.Errors()[0].Summary())
+				require.Nil(t, service)
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError())
+			require.Equal(t, test.expectedID, service.ID)
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"reflect"
 	"regexp"
 	"time"
@@ -25,7 +27,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdkresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
 )
@@ -52,6 +53,22 @@ var allowListElementType = types.ObjectType{
 	},
 }
 
+var servicePortElementType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":    types.StringType,
+		"port":    types.Int64Type,
+		"purpose": types.StringType,
+	},
+}
+
+var serviceEndpointElementType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":       types.StringType,
+		"ports":      types.ListType{ElemType: servicePortElementType},
+		"visibility": types.StringType,
+	},
+}
+
 var privateConnectMechanisms = []string{"privateconnect", "privatelink"}
 
 func NewServiceResource() resource.Resource {
@@ -60,7 +77,9 @@ func NewServiceResource() resource.Resource {
 
 // ServiceResource defines the resource implementation.
 type ServiceResource struct {
-	client *skysql.Client
+	client                skysql.API
+	skipCatalogValidation bool
+	defaultProjectID      string
 }
 
 // ServiceResourceModel describes the resource data model.
@@ -72,6 +91,7 @@ type ServiceResourceModel struct {
 	Provider           types.String   `tfsdk:"cloud_provider"`
 	Region             types.String   `tfsdk:"region"`
 	Version            types.String   `tfsdk:"version"`
+	AllowMajorUpgrade  types.Bool     `tfsdk:"allow_major_version_upgrade"`
 	Nodes              types.Int64    `tfsdk:"nodes"`
 	Architecture       types.String   `tfsdk:"architecture"`
 	Size               types.String   `tfsdk:"size"`
@@ -97,12 +117,22 @@ type ServiceResourceModel struct {
 	MaxscaleSize       types.String   `tfsdk:"maxscale_size"`
 	FQDN               types.String   `tfsdk:"fqdn"`
 	AvailabilityZone   types.String   `tfsdk:"availability_zone"`
+	Endpoints          types.List     `tfsdk:"endpoints"`
+	OutboundIPs        types.List     `tfsdk:"outbound_ips"`
+}
+
+// ServiceResourceEndpointModel is an endpoint of the service
+type ServiceResourceEndpointModel struct {
+	Name       types.String                    `tfsdk:"name"`
+	Ports      []ServiceResourceNamedPortModel `tfsdk:"ports"`
+	Visibility types.String                    `tfsdk:"visibility"`
 }
 
 // ServiceResourceNamedPortModel is an endpoint port
 type ServiceResourceNamedPortModel struct {
-	Name types.String `tfsdk:"name"`
-	Port types.Int64  `tfsdk:"port"`
+	Name    types.String `tfsdk:"name"`
+	Port    types.Int64  `tfsdk:"port"`
+	Purpose types.String `tfsdk:"purpose"`
 }
 
 func (r *ServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -139,7 +169,7 @@ var serviceResourceSchemaV0 = schema.Schema{
 		"project_id": schema.StringAttribute{
 			Required:    false,
 			Optional:    true,
-			Description: "The ID of the project to create the service in",
+			Description: "The ID of the project to create the service in. Defaults to the project of the provider profile, if any",
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.RequiresReplace(),
 			},
@@ -160,7 +190,7 @@ var serviceResourceSchemaV0 = schema.Schema{
 		},
 		"region": schema.StringAttribute{
 			Required:    true,
-			Description: "The region to create the service in. Value should be valid for a specific cloud provider",
+			Description: "The region to create the service in. Value should be valid for a specific cloud provider, see the skysql_regions data source",
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.RequiresReplace(),
 			},
@@ -168,12 +198,15 @@ var serviceResourceSchemaV0 = schema.Schema{
 		"version": schema.StringAttribute{
 			Optional:    true,
 			Computed:    true,
-			Description: "The software version",
+			Description: "The software version. Changing it upgrades the service in place to a newer version of the same topology",
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
-				stringplanmodifier.RequiresReplace(),
 			},
 		},
+		"allow_major_version_upgrade": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Whether to allow upgrading the service to a new major version, e.g. from 10.6 to 10.11. Valid values are: true or false",
+		},
 		"nodes": schema.Int64Attribute{
 			Optional:    true,
 			Computed:    true,
@@ -201,7 +234,7 @@ var serviceResourceSchemaV0 = schema.Schema{
 		},
 		"topology": schema.StringAttribute{
 			Required:    true,
-			Description: "The topology of the service. Valid values are: es-single, es-replica, xpand, csdw and sa",
+			Description: "The topology of the service. Valid values are: es-single, es-replica, xpand, csdw and sa, see the skysql_topologies data source",
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.RequiresReplace(),
 			},
@@ -395,6 +428,53 @@ var serviceResourceSchemaV0 = schema.Schema{
 				stringplanmodifier.RequiresReplace(),
 			},
 		},
+		"endpoints": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The endpoints of the service with their ports. The ports are only available when the service is in the ready state",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "The name of the endpoint",
+					},
+					"ports": schema.ListNestedAttribute{
+						Computed:    true,
+						Description: "The ports of the endpoint",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Computed:    true,
+									Description: "The name of the port, e.g. readwrite",
+								},
+								"port": schema.Int64Attribute{
+									Computed:    true,
+									Description: "The port number",
+								},
+								"purpose": schema.StringAttribute{
+									Computed:    true,
+									Description: "The purpose of the port, e.g. readwrite",
+								},
+							},
+						},
+					},
+					"visibility": schema.StringAttribute{
+						Computed:    true,
+						Description: "The visibility of the endpoint: public or private",
+					},
+				},
+			},
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"outbound_ips": schema.ListAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The IP addresses the service connects from, e.g. to allow the replication from an external primary in a firewall",
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+		},
 	},
 	Blocks: map[string]schema.Block{
 		"timeouts": timeouts.Block(context.Background(), timeouts.Opts{
@@ -415,21 +495,28 @@ func (r *ServiceResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*skysql.Client)
+	client, ok := req.ProviderData.(skysql.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
 	r.client = client
+	if data, ok := req.ProviderData.(*providerClient); ok {
+		r.skipCatalogValidation = data.skipCatalogValidation
+		r.defaultProjectID = data.defaultProjectID
+	}
 }
 
 func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "skysql_service.Create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state *ServiceResourceModel
 
 	// Read Terraform state into the model
@@ -441,7 +528,7 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 
 	createServiceRequest := &provisioning.CreateServiceRequest{
 		Name:               state.Name.ValueString(),
-		ProjectID:          state.ProjectID.ValueString(),
+		ProjectID:          firstNonEmpty(state.ProjectID.ValueString(), r.defaultProjectID),
 		ServiceType:        state.ServiceType.ValueString(),
 		Provider:           state.Provider.ValueString(),
 		Region:             state.Region.ValueString(),
@@ -470,7 +557,7 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 
 	b, err := json.Marshal(createServiceRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to marshal create service request", err.Error())
+		resp.Diagnostics.AddError("Failed to marshal create service request", errorDetail(err))
 		return
 	}
 	tflog.Debug(ctx, string(b))
@@ -499,14 +586,35 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 		}
 	}
 
+	createStartedAt := time.Now()
 	service, err := r.client.CreateService(ctx, createServiceRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating service", err.Error())
-		return
+		if !isAmbiguousCreateError(err) {
+			resp.Diagnostics.AddError("Error creating service", errorDetail(err))
+			return
+		}
+
+		tflog.Warn(ctx, "Create service request failed with an ambiguous error, looking for the service", map[string]interface{}{
+			"name":  createServiceRequest.Name,
+			"error": err.Error(),
+		})
+
+		existingService, findErr := r.findCreatedService(ctx, createServiceRequest, createStartedAt)
+		if findErr != nil || existingService == nil {
+			resp.Diagnostics.AddError("Error creating service", errorDetail(err))
+			return
+		}
+
+		resp.Diagnostics.AddWarning("Adopted existing service",
+			fmt.Sprintf("The create request for the service %q failed with error: %s. "+
+				"A service with the same name was found with ID %q, it is adopted instead of creating a new one.",
+				existingService.Name, err, existingService.ID))
+		service = existingService
 	}
 
 	// save into the Terraform state.
 	state.ID = types.StringValue(service.ID)
+	span.SetAttributes(skysql.ServiceIDKey.String(service.ID))
 	state.Name = types.StringValue(service.Name)
 	state.FQDN = types.StringValue(service.FQDN)
 
@@ -531,6 +639,7 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 	state.Storage = types.Int64Value(int64(service.StorageVolume.Size))
 	state.SSLEnabled = types.BoolValue(service.SSLEnabled)
 	state.AvailabilityZone = types.StringValue(service.AvailabilityZone)
+	r.setEndpoints(ctx, state, service)
 	if len(service.Endpoints) > 0 {
 		state.Mechanism = types.StringValue(service.Endpoints[0].Mechanism)
 		r.setAllowAccounts(ctx, state, service.Endpoints[0].AllowedAccounts)
@@ -550,31 +659,17 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	if state.WaitForCreation.ValueBool() {
-		createTimeout, diagsErr := state.Timeouts.Create(ctx, defaultCreateTimeout)
+		createTimeout, diagsErr := operationTimeout(ctx, state.Timeouts, timeoutCreate, defaultCreateTimeout)
 		if diagsErr != nil {
-			diagsErr.AddError("Error creating service", fmt.Sprintf("Unable to create service, got error: %s", err))
 			resp.Diagnostics.Append(diagsErr...)
+			return
 		}
 
-		err = sdkresource.RetryContext(ctx, createTimeout, func() *sdkresource.RetryError {
-			service, err := r.client.GetServiceByID(ctx, service.ID)
-			if err != nil {
-				return sdkresource.NonRetryableError(fmt.Errorf("error retrieving service details: %v", err))
-			}
-
-			if service.Status != "ready" && service.Status != "failed" {
-				return sdkresource.RetryableError(fmt.Errorf("expected instance to be ready or failed state but was in state %s", service.Status))
-			}
-
-			if service.Status == "failed" {
-				return sdkresource.NonRetryableError(errors.New("service creation failed"))
-			}
-
-			return nil
-		})
+		err = newOperationWaiter(createTimeout, []string{"ready"}, serviceFailureStates).
+			Wait(ctx, service.ID, serviceStatus(r.client, service.ID))
 
 		if err != nil {
-			resp.Diagnostics.AddError("Error creating service", fmt.Sprintf("Unable to create service, got error: %s", err))
+			resp.Diagnostics.AddError("Error creating service", fmt.Sprintf("Unable to create service, got error: %s", errorDetail(err)))
 			return
 		}
 		var plan *ServiceResourceModel
@@ -591,6 +686,76 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 }
 
+// isAmbiguousCreateError reports whether the service may have been created despite the error,
+// e.g. when the response was lost in transit or the gateway timed out.
+// A conflict is not ambiguous: the service belongs to someone else and must not be adopted.
+func isAmbiguousCreateError(err error) bool {
+	var apiErr *skysql.APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	return apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+// adoptClockSkew is how far the clock of the API may be behind the local clock
+// when comparing the creation time of a service with the start of the create request.
+const adoptClockSkew = 5 * time.Minute
+
+// findCreatedService looks for a service that matches the create request and was created
+// after the request started. It returns nil when there is no such service or the match is not unique.
+func (r *ServiceResource) findCreatedService(ctx context.Context, req *provisioning.CreateServiceRequest, startedAt time.Time) (*provisioning.Service, error) {
+	services, err := r.client.FindServicesByName(ctx, req.Name, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *provisioning.Service
+	for i := range services {
+		if services[i].Provider != req.Provider ||
+			services[i].Region != req.Region ||
+			services[i].Topology != req.Topology {
+			continue
+		}
+		// created_on is set by the API clock, with a resolution of seconds
+		if time.Unix(int64(services[i].CreatedOn), 0).Before(startedAt.Add(-adoptClockSkew).Truncate(time.Second)) {
+			continue
+		}
+		if found != nil {
+			tflog.Warn(ctx, "More than one service matches the create request", map[string]interface{}{
+				"name": req.Name,
+			})
+			return nil, nil
+		}
+		found = &services[i]
+	}
+	return found, nil
+}
+
+// setEndpoints sets the endpoints and the outbound IPs of the service.
+func (r *ServiceResource) setEndpoints(ctx context.Context, data *ServiceResourceModel, service *provisioning.Service) {
+	endpoints := make([]ServiceResourceEndpointModel, 0, len(service.Endpoints))
+	for _, endpoint := range service.Endpoints {
+		ports := make([]ServiceResourceNamedPortModel, 0, len(endpoint.Ports))
+		for _, port := range endpoint.Ports {
+			ports = append(ports, ServiceResourceNamedPortModel{
+				Name:    types.StringValue(port.Name),
+				Port:    types.Int64Value(int64(port.Port)),
+				Purpose: types.StringValue(port.Purpose),
+			})
+		}
+		endpoints = append(endpoints, ServiceResourceEndpointModel{
+			Name:       types.StringValue(endpoint.Name),
+			Ports:      ports,
+			Visibility: types.StringValue(endpoint.Visibility),
+		})
+	}
+	data.Endpoints, _ = types.ListValueFrom(ctx, serviceEndpointElementType, endpoints)
+
+	outboundIPs := make([]string, 0, len(service.OutboundIps))
+	outboundIPs = append(outboundIPs, service.OutboundIps...)
+	data.OutboundIPs, _ = types.ListValueFrom(ctx, types.StringType, outboundIPs)
+}
+
 func (r *ServiceResource) setAllowAccounts(ctx context.Context, data *ServiceResourceModel, allowedAccounts []string) {
 	data.AllowedAccounts, _ = types.ListValueFrom(ctx, types.StringType, allowedAccounts)
 }
@@ -615,6 +780,9 @@ func (r *ServiceResource) allowListToListType(ctx context.Context, allowList []p
 }
 
 func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "skysql_service.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state *ServiceResourceModel
 
 	// Read Terraform prior state into the model
@@ -624,6 +792,8 @@ func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(state.ID.ValueString()))
+
 	err := r.readServiceState(ctx, state)
 	if err != nil {
 		if errors.Is(err, skysql.ErrorServiceNotFound) {
@@ -634,7 +804,7 @@ func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 			return
 		}
-		resp.Diagnostics.AddError("Can not read service", err.Error())
+		resp.Diagnostics.AddError("Can not read service", errorDetail(err))
 		return
 	}
 	var plan *ServiceResourceModel
@@ -684,6 +854,7 @@ func (r *ServiceResource) readServiceState(ctx context.Context, data *ServiceRes
 	}
 	data.IsActive = types.BoolValue(service.IsActive)
 	data.SSLEnabled = types.BoolValue(service.SSLEnabled)
+	r.setEndpoints(ctx, data, service)
 	if len(service.Endpoints) > 0 {
 		data.Mechanism = types.StringValue(service.Endpoints[0].Mechanism)
 		r.setAllowAccounts(ctx, data, service.Endpoints[0].AllowedAccounts)
@@ -702,6 +873,9 @@ func (r *ServiceResource) readServiceState(ctx context.Context, data *ServiceRes
 }
 
 func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "skysql_service.Update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var plan *ServiceResourceModel
 	var state *ServiceResourceModel
 
@@ -719,11 +893,14 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(state.ID.ValueString()))
+
 	state.WaitForUpdate = plan.WaitForUpdate
 	state.WaitForCreation = plan.WaitForCreation
 	state.WaitForDeletion = plan.WaitForDeletion
 	state.Timeouts = plan.Timeouts
 	state.DeletionProtection = plan.DeletionProtection
+	state.AllowMajorUpgrade = plan.AllowMajorUpgrade
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -735,6 +912,11 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	r.updateServiceVersion(ctx, plan, state, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.updateServiceEndpoints(ctx, plan, state, resp)
 	if resp.Diagnostics.HasError() {
 		return
@@ -770,7 +952,7 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 
 			return
 		}
-		resp.Diagnostics.AddError("Can not read service", err.Error())
+		resp.Diagnostics.AddError("Can not read service", errorDetail(err))
 		return
 	}
 
@@ -800,6 +982,58 @@ func (r *ServiceResource) updateAllowListState(plan *ServiceResourceModel, state
 	}
 }
 
+func (r *ServiceResource) updateServiceVersion(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
+	if plan.Version.IsUnknown() || plan.Version.ValueString() == state.Version.ValueString() {
+		return
+	}
+
+	tflog.Info(ctx, "Upgrading service version", map[string]interface{}{
+		"id":   state.ID.ValueString(),
+		"from": state.Version.ValueString(),
+		"to":   plan.Version.ValueString(),
+	})
+
+	versions, err := r.client.GetVersions(ctx, func(values url.Values) {
+		values.Set("topology", state.Topology.ValueString())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error upgrading the service version", fmt.Sprintf("Unable to read the available versions, got error: %s", errorDetail(err)))
+		return
+	}
+
+	err = validateVersionUpgrade(versions, state.Topology.ValueString(), state.Version.ValueString(), plan.Version.ValueString(), plan.AllowMajorUpgrade.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "Invalid version upgrade", err.Error())
+		return
+	}
+
+	err = r.client.UpgradeServiceVersion(ctx, state.ID.ValueString(), plan.Version.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error upgrading the service version", fmt.Sprintf("Unable to upgrade the service version, got error: %s", errorDetail(err)))
+		return
+	}
+
+	state.Version = plan.Version
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The service can't take any other change while it is being upgraded,
+	// so the upgrade is awaited even when wait_for_update is false.
+	// A service stopped in the same apply settles back to stopped.
+	updateTimeout, diagsErr := operationTimeout(ctx, state.Timeouts, timeoutUpdate, defaultUpdateTimeout)
+	if diagsErr != nil {
+		resp.Diagnostics.Append(diagsErr...)
+		return
+	}
+	err = newOperationWaiter(updateTimeout, serviceUpdateWaitStates, serviceFailureStates).
+		Wait(ctx, state.ID.ValueString(), serviceStatus(r.client, state.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Error upgrading the service version", fmt.Sprintf("Unable to upgrade the service version, got error: %s", errorDetail(err)))
+	}
+}
+
 func (r *ServiceResource) updateServiceStorage(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, resp *resource.UpdateResponse) {
 	if plan.Storage.ValueInt64() != state.Storage.ValueInt64() || plan.VolumeIOPS.ValueInt64() != state.VolumeIOPS.ValueInt64() {
 		tflog.Info(ctx, "Updating storage size for the service", map[string]interface{}{
@@ -813,7 +1047,7 @@ func (r *ServiceResource) updateServiceStorage(ctx context.Context, plan *Servic
 		err := r.client.ModifyServiceStorage(ctx, state.ID.ValueString(), plan.Storage.ValueInt64(), plan.VolumeIOPS.ValueInt64())
 		if err != nil {
 			resp.Diagnostics.AddError("Error updating a storage for the service",
-				fmt.Sprintf("Unable to update a storage size for the service, got error: %s", err))
+				fmt.Sprintf("Unable to update a storage size for the service, got error: %s", errorDetail(err)))
 			return
 		}
 
@@ -837,7 +1071,7 @@ func (r *ServiceResource) updateNumberOfNodeForService(ctx context.Context, plan
 
 		err := r.client.ModifyServiceNodeNumber(ctx, state.ID.ValueString(), plan.Nodes.ValueInt64())
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating a number of nodes for the service", fmt.Sprintf("Unable to update a nodes number for the service, got error: %s", err))
+			resp.Diagnostics.AddError("Error updating a number of nodes for the service", fmt.Sprintf("Unable to update a nodes number for the service, got error: %s", errorDetail(err)))
 			return
 		}
 
@@ -860,7 +1094,7 @@ func (r *ServiceResource) updateServiceSize(ctx context.Context, plan *ServiceRe
 
 		err := r.client.ModifyServiceSize(ctx, state.ID.ValueString(), plan.Size.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating service size", fmt.Sprintf("Unable to update service size, got error: %s", err))
+			resp.Diagnostics.AddError("Error updating service size", fmt.Sprintf("Unable to update service size, got error: %s", errorDetail(err)))
 			return
 		}
 
@@ -912,7 +1146,7 @@ func (r *ServiceResource) updateServiceEndpoints(ctx context.Context, plan *Serv
 			planAllowedAccounts,
 			visibility)
 		if err != nil {
-			resp.Diagnostics.AddError("Can not update service", err.Error())
+			resp.Diagnostics.AddError("Can not update service", errorDetail(err))
 			return
 		}
 
@@ -967,7 +1201,7 @@ func (r *ServiceResource) updateAllowList(ctx context.Context, plan *ServiceReso
 
 					return
 				}
-				resp.Diagnostics.AddError("Error updating service allow list", err.Error())
+				resp.Diagnostics.AddError("Error updating service allow list", errorDetail(err))
 				return
 			}
 
@@ -997,7 +1231,7 @@ func (r *ServiceResource) updateServicePowerState(ctx context.Context, plan *Ser
 		})
 		err := r.client.SetServicePowerState(ctx, state.ID.ValueString(), plan.IsActive.ValueBool())
 		if err != nil {
-			resp.Diagnostics.AddError("Can not update service", err.Error())
+			resp.Diagnostics.AddError("Can not update service", errorDetail(err))
 			return
 		}
 		state.IsActive = plan.IsActive
@@ -1010,34 +1244,31 @@ func (r *ServiceResource) updateServicePowerState(ctx context.Context, plan *Ser
 	}
 }
 
-var serviceUpdateWaitStates = []string{"ready", "failed", "stopped"}
+var serviceUpdateWaitStates = []string{"ready", "stopped"}
+
+// serviceFailureStates end the wait for a service operation with an error.
+var serviceFailureStates = []string{"failed", serviceStatusDeleted}
 
 func (r *ServiceResource) waitForUpdate(ctx context.Context, state *ServiceResourceModel, resp *resource.UpdateResponse) {
 	if state.WaitForUpdate.ValueBool() {
-		err := sdkresource.RetryContext(ctx, defaultUpdateTimeout, func() *sdkresource.RetryError {
-			service, err := r.client.GetServiceByID(ctx, state.ID.ValueString())
-			if err != nil {
-				return sdkresource.NonRetryableError(fmt.Errorf("error retrieving service details: %v", err))
-			}
-
-			if Contains[string](serviceUpdateWaitStates, service.Status) {
-				return nil
-			}
-
-			if service.Status == "failed" {
-				return sdkresource.NonRetryableError(errors.New("service creation failed"))
-			}
-
-			return sdkresource.RetryableError(fmt.Errorf("expected instance to be ready or failed or stopped state but was in state %s", service.Status))
-		})
+		updateTimeout, diagsErr := operationTimeout(ctx, state.Timeouts, timeoutUpdate, defaultUpdateTimeout)
+		if diagsErr != nil {
+			resp.Diagnostics.Append(diagsErr...)
+			return
+		}
 
+		err := newOperationWaiter(updateTimeout, serviceUpdateWaitStates, serviceFailureStates).
+			Wait(ctx, state.ID.ValueString(), serviceStatus(r.client, state.ID.ValueString()))
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating service", fmt.Sprintf("Unable to update service, got error: %s", err))
+			resp.Diagnostics.AddError("Error updating service", fmt.Sprintf("Unable to update service, got error: %s", errorDetail(err)))
 		}
 	}
 }
 
 func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "skysql_service.Delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var state *ServiceResourceModel
 
 	// Read Terraform prior state data into the model
@@ -1047,6 +1278,8 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	span.SetAttributes(skysql.ServiceIDKey.String(state.ID.ValueString()))
+
 	if state.DeletionProtection.ValueBool() {
 		resp.Diagnostics.AddError("Can not delete service", "Deletion protection is enabled")
 		return
@@ -1062,30 +1295,22 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 			return
 		}
+		resp.Diagnostics.AddError("Error deleting service", errorDetail(err))
 		return
 	}
 
 	if state.WaitForDeletion.ValueBool() {
-		deleteTimeout, diagsErr := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+		deleteTimeout, diagsErr := operationTimeout(ctx, state.Timeouts, timeoutDelete, defaultDeleteTimeout)
 		if diagsErr != nil {
-			diagsErr.AddError("Error deleting service", fmt.Sprintf("Unable to delete service, got error: %s", err))
 			resp.Diagnostics.Append(diagsErr...)
+			return
 		}
 
-		err = sdkresource.RetryContext(ctx, deleteTimeout, func() *sdkresource.RetryError {
-			service, err := r.client.GetServiceByID(ctx, state.ID.ValueString())
-			if err != nil {
-				if errors.Is(err, skysql.ErrorServiceNotFound) {
-					return nil
-				}
-				return sdkresource.NonRetryableError(fmt.Errorf("error retrieving service details: %v", err))
-			}
-
-			return sdkresource.RetryableError(fmt.Errorf("expected that the instance was deleted, but it was in state %s", service.Status))
-		})
+		err = newOperationWaiter(deleteTimeout, []string{serviceStatusDeleted}, nil).
+			Wait(ctx, state.ID.ValueString(), serviceStatus(r.client, state.ID.ValueString()))
 
 		if err != nil {
-			resp.Diagnostics.AddError("Error delete service", fmt.Sprintf("Unable to delete service, got error: %s", err))
+			resp.Diagnostics.AddError("Error delete service", fmt.Sprintf("Unable to delete service, got error: %s", errorDetail(err)))
 		}
 	}
 }
@@ -1250,6 +1475,12 @@ func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 				"Attempt to modify read-only attribute",
 				fmt.Sprintf("The argument %q is read only for the %q topology", "version", plan.Topology.ValueString()))
 		}
+
+		if state != nil && !plan.Version.IsUnknown() && plan.Version.ValueString() != state.Version.ValueString() {
+			resp.Diagnostics.AddAttributeError(path.Root("version"),
+				"Attempt to modify read-only attribute",
+				fmt.Sprintf("The argument %q is read only for the %q topology", "version", plan.Topology.ValueString()))
+		}
 	}
 
 	if state != nil && plan.Architecture.ValueString() != state.Architecture.ValueString() {
@@ -1264,11 +1495,7 @@ func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 				"Please explicitly destroy this service before changing its ssl_enabled.")
 	}
 
-	if state != nil && plan.Version.ValueString() != state.Version.ValueString() {
-		resp.Diagnostics.AddError("Cannot change service version",
-			"To prevent accidental deletion of data, changing version isn't allowed. "+
-				"Please explicitly destroy this service before changing its version.")
-	}
+	r.validateCatalog(ctx, plan, state, &resp.Diagnostics)
 
 	if state == nil &&
 		Contains[string](privateConnectMechanisms, plan.Mechanism.ValueString()) &&
@@ -1287,6 +1514,14 @@ func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 	if state != nil && !state.AllowList.IsUnknown() && plan.AllowList.IsNull() {
 		resp.Plan.SetAttribute(ctx, path.Root("allow_list"), state.AllowList)
 	}
+
+	// The endpoints and outbound IPs are kept from the state only when nothing changes.
+	// Any in-place update (e.g. stopping the service or changing the allow list) is read back
+	// from the API and may change them.
+	if state != nil && !resp.Plan.Raw.Equal(req.State.Raw) {
+		resp.Plan.SetAttribute(ctx, path.Root("endpoints"), types.ListUnknown(serviceEndpointElementType))
+		resp.Plan.SetAttribute(ctx, path.Root("outbound_ips"), types.ListUnknown(types.StringType))
+	}
 }
 
 func (r *ServiceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
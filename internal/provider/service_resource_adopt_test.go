@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceResourceFindCreatedService(t *testing.T) {
+	createRequest := &provisioning.CreateServiceRequest{
+		Name:      "orders-prod",
+		ProjectID: "project-1",
+		Provider:  "gcp",
+		Region:    "us-central1",
+		Topology:  "es-single",
+	}
+	startedAt := time.Unix(1700000000, 0)
+	createdOn := int(startedAt.Unix()) + 5
+
+	tests := []struct {
+		name      string
+		services  []provisioning.Service
+		expectID  string
+		expectNil bool
+	}{
+		{
+			name: "adopts the matching service",
+			services: []provisioning.Service{
+				{ID: "dbdgf42002418", Name: "orders-prod", CreatedOn: createdOn, ProjectID: "project-1", Provider: "gcp", Region: "us-central1", Topology: "es-single"},
+				{ID: "dbdgf42002419", Name: "orders-stage", CreatedOn: createdOn, ProjectID: "project-1", Provider: "gcp", Region: "us-central1", Topology: "es-single"},
+			},
+			expectID: "dbdgf42002418",
+		},
+		{
+			name: "ignores services with a different placement",
+			services: []provisioning.Service{
+				{ID: "dbdgf42002418", Name: "orders-prod", CreatedOn: createdOn, ProjectID: "project-1", Provider: "aws", Region: "us-east-1", Topology: "es-single"},
+			},
+			expectNil: true,
+		},
+		{
+			name: "ignores services of another project",
+			services: []provisioning.Service{
+				{ID: "dbdgf42002418", Name: "orders-prod", CreatedOn: createdOn, ProjectID: "project-2", Provider: "gcp", Region: "us-central1", Topology: "es-single"},
+			},
+			expectNil: true,
+		},
+		{
+			name: "ignores services created before the request",
+			services: []provisioning.Service{
+				{ID: "dbdgf42002418", Name: "orders-prod", CreatedOn: int(startedAt.Unix()) - 3600, ProjectID: "project-1", Provider: "gcp", Region: "us-central1", Topology: "es-single"},
+			},
+			expectNil: true,
+		},
+		{
+			name: "allows for the clock of the API to be behind",
+			services: []provisioning.Service{
+				{ID: "dbdgf42002418", Name: "orders-prod", CreatedOn: int(startedAt.Unix()) - 30, ProjectID: "project-1", Provider: "gcp", Region: "us-central1", Topology: "es-single"},
+			},
+			expectID: "dbdgf42002418",
+		},
+		{
+			name: "ignores services without a project",
+			services: []provisioning.Service{
+				{ID: "dbdgf42002418", Name: "orders-prod", CreatedOn: createdOn, Provider: "gcp", Region: "us-central1", Topology: "es-single"},
+			},
+			expectNil: true,
+		},
+		{
+			name: "does not guess when the match is not unique",
+			services: []provisioning.Service{
+				{ID: "dbdgf42002418", Name: "orders-prod", CreatedOn: createdOn, ProjectID: "project-1", Provider: "gcp", Region: "us-central1", Topology: "es-single"},
+				{ID: "dbdgf42002419", Name: "orders-prod", CreatedOn: createdOn, ProjectID: "project-1", Provider: "gcp", Region: "us-central1", Topology: "es-single"},
+			},
+			expectNil: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			res := &ServiceResource{}
+			configureResp := &resource.ConfigureResponse{}
+			res.Configure(context.Background(), resource.ConfigureRequest{
+				ProviderData: &fakeSkySQLAPI{services: test.services},
+			}, configureResp)
+			r.False(configureResp.Diagnostics.HasError())
+
+			service, err := res.findCreatedService(context.Background(), createRequest, startedAt)
+			r.NoError(err)
+			if test.expectNil {
+				r.Nil(service)
+				return
+			}
+			r.NotNil(service)
+			r.Equal(test.expectID, service.ID)
+		})
+	}
+}
+
+func TestIsAmbiguousCreateError(t *testing.T) {
+	r := require.New(t)
+
+	r.True(isAmbiguousCreateError(errors.New("connection reset by peer")))
+	r.True(isAmbiguousCreateError(&skysql.APIError{StatusCode: http.StatusGatewayTimeout}))
+	r.False(isAmbiguousCreateError(&skysql.APIError{StatusCode: http.StatusConflict}))
+	r.False(isAmbiguousCreateError(&skysql.APIError{StatusCode: http.StatusBadRequest}))
+	r.False(isAmbiguousCreateError(&skysql.APIError{StatusCode: http.StatusUnauthorized}))
+}
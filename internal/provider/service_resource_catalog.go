@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// validateCatalog checks the attributes that are new or changed against the SkySQL catalog,
+// so an invalid combination fails at plan time instead of after a long apply.
+// The catalog is best effort: when it can't be read, a warning is added and the API
+// validates the request on apply.
+func (r *ServiceResource) validateCatalog(ctx context.Context, plan *ServiceResourceModel, state *ServiceResourceModel, diags *diag.Diagnostics) {
+	if r.client == nil || r.skipCatalogValidation {
+		return
+	}
+
+	var prior ServiceResourceModel
+	if state != nil {
+		prior = *state
+	}
+
+	// The checks that depend on an invalid topology or region are skipped, so only the root cause is reported
+	topologyValid, regionValid := true, true
+	topology := plan.Topology.ValueString()
+	serverless := Contains[string]([]string{"lakehouse", "sa"}, topology)
+
+	if changed(plan.Topology, prior.Topology) || changed(plan.ServiceType, prior.ServiceType) {
+		topologies, err := r.client.GetTopologies(ctx)
+		if err != nil {
+			addCatalogWarning(diags, "topologies", err)
+		} else {
+			names := make([]string, 0, len(topologies))
+			serviceTypes := make([]string, 0, len(topologies))
+			for _, t := range topologies {
+				names = append(names, t.Name)
+				if t.ServiceType != "" {
+					serviceTypes = append(serviceTypes, t.ServiceType)
+				}
+			}
+			serviceTypeValid := validateCatalogValue(diags, path.Root("service_type"), plan.ServiceType, serviceTypes, "")
+			topologyValid = validateCatalogValue(diags, path.Root("topology"), plan.Topology, names, "")
+			for _, t := range topologies {
+				if serviceTypeValid && t.Name == topology && isKnown(plan.ServiceType) && t.ServiceType != "" && t.ServiceType != plan.ServiceType.ValueString() {
+					diags.AddAttributeError(path.Root("service_type"),
+						"Invalid service_type value",
+						fmt.Sprintf("The %q topology requires service_type = %q", topology, t.ServiceType))
+				}
+			}
+		}
+	}
+
+	if isKnown(plan.Provider) && (changed(plan.Region, prior.Region) || changed(plan.Provider, prior.Provider)) {
+		regions, err := r.client.GetRegions(ctx, func(values url.Values) {
+			values.Set("provider", plan.Provider.ValueString())
+		})
+		if err != nil {
+			addCatalogWarning(diags, "regions", err)
+		} else {
+			names := make([]string, 0, len(regions))
+			for _, region := range regions {
+				names = append(names, region.Name)
+			}
+			regionValid = validateCatalogValue(diags, path.Root("region"), plan.Region, names,
+				fmt.Sprintf(" for the %q provider", plan.Provider.ValueString()))
+		}
+	}
+
+	if !serverless && topologyValid && isKnown(plan.Topology) && changed(plan.Version, prior.Version) {
+		versions, err := r.client.GetVersions(ctx, func(values url.Values) {
+			values.Set("topology", topology)
+		})
+		if err != nil {
+			addCatalogWarning(diags, "versions", err)
+		} else {
+			names := make([]string, 0, len(versions))
+			for _, version := range versions {
+				if version.Topology == "" || version.Topology == topology {
+					names = append(names, version.Name)
+				}
+			}
+			validateCatalogValue(diags, path.Root("version"), plan.Version, names,
+				fmt.Sprintf(" for the %q topology", topology))
+			if state != nil && Contains[string](names, plan.Version.ValueString()) {
+				err = validateVersionUpgrade(versions, topology, state.Version.ValueString(), plan.Version.ValueString(), plan.AllowMajorUpgrade.ValueBool())
+				if err != nil {
+					diags.AddAttributeError(path.Root("version"), "Invalid version upgrade", err.Error())
+				}
+			}
+		}
+	}
+
+	// The sizes of the provider are read once, for the architectures and the sizes
+	sizesChanged := !serverless && topologyValid && isKnown(plan.Topology) &&
+		(changed(plan.Size, prior.Size) || changed(plan.MaxscaleSize, prior.MaxscaleSize))
+	if isKnown(plan.Provider) && (changed(plan.Architecture, prior.Architecture) || sizesChanged) {
+		sizes, err := r.client.GetSizes(ctx, func(values url.Values) {
+			values.Set("provider", plan.Provider.ValueString())
+		})
+		if err != nil {
+			addCatalogWarning(diags, "sizes", err)
+		} else {
+			// The API may ignore some of the filters, so they are applied to the result as well
+			sizes = sizeFilter{provider: plan.Provider.ValueString()}.apply(sizes)
+			validateSizes(diags, plan, prior, sizes, sizesChanged)
+		}
+	}
+
+	if !serverless && isKnown(plan.Provider) && isKnownInt64(plan.Storage) && plan.Storage.ValueInt64() != prior.Storage.ValueInt64() {
+		storageSizes, err := r.client.GetStorageSizes(ctx, func(values url.Values) {
+			values.Set("provider", plan.Provider.ValueString())
+		})
+		if err != nil {
+			addCatalogWarning(diags, "storage sizes", err)
+		} else {
+			validateStorageSize(diags, plan.Storage, storageSizes, plan.Provider.ValueString())
+		}
+	}
+
+	if regionValid && isKnown(plan.Region) && changed(plan.AvailabilityZone, prior.AvailabilityZone) {
+		zones, err := r.client.GetAvailabilityZones(ctx, plan.Region.ValueString(), func(values url.Values) {
+			if isKnown(plan.Provider) {
+				values.Set("provider", plan.Provider.ValueString())
+			}
+		})
+		if err != nil {
+			addCatalogWarning(diags, "availability zones", err)
+		} else {
+			names := make([]string, 0, len(zones))
+			for _, zone := range zones {
+				names = append(names, zone.Name)
+			}
+			validateCatalogValue(diags, path.Root("availability_zone"), plan.AvailabilityZone, names,
+				fmt.Sprintf(" in the %q region", plan.Region.ValueString()))
+		}
+	}
+}
+
+// validateSizes checks the architecture against the architectures of the provider sizes and, when
+// sizesChanged is set, the sizes against the sizes of the topology and architecture.
+func validateSizes(diags *diag.Diagnostics, plan *ServiceResourceModel, prior ServiceResourceModel, sizes []provisioning.Size, sizesChanged bool) {
+	architectureValid := true
+	if changed(plan.Architecture, prior.Architecture) && len(sizes) > 0 {
+		architectures := make([]string, 0, len(sizes))
+		for _, size := range sizes {
+			architectures = append(architectures, size.Architecture)
+		}
+		architectureValid = validateCatalogValue(diags, path.Root("architecture"), plan.Architecture, architectures,
+			fmt.Sprintf(" for the %q provider", plan.Provider.ValueString()))
+	}
+	if !sizesChanged || !architectureValid {
+		return
+	}
+
+	topology := plan.Topology.ValueString()
+	for _, size := range []struct {
+		attribute string
+		value     types.String
+		prior     types.String
+		sizeType  string
+	}{
+		{"size", plan.Size, prior.Size, "server"},
+		{"maxscale_size", plan.MaxscaleSize, prior.MaxscaleSize, "maxscale"},
+	} {
+		if !changed(size.value, size.prior) {
+			continue
+		}
+		filter := sizeFilter{provider: plan.Provider.ValueString(), topology: topology, sizeType: size.sizeType}
+		if isKnown(plan.Architecture) {
+			filter.architecture = plan.Architecture.ValueString()
+		}
+		names := make([]string, 0, len(sizes))
+		for _, s := range filter.apply(sizes) {
+			names = append(names, s.Name)
+		}
+		validateCatalogValue(diags, path.Root(size.attribute), size.value, names,
+			fmt.Sprintf(" for the %q topology in the %q provider", topology, plan.Provider.ValueString()))
+	}
+}
+
+// validateStorageSize adds an attribute error listing the storage sizes of the provider when the storage isn't one of them.
+// An empty catalog isn't enforced.
+func validateStorageSize(diags *diag.Diagnostics, storage types.Int64, storageSizes []provisioning.StorageSize, provider string) {
+	allowed := make([]int64, 0, len(storageSizes))
+	for _, storageSize := range storageSizes {
+		if (storageSize.Provider == "" || storageSize.Provider == provider) && !Contains[int64](allowed, storageSize.Size) {
+			allowed = append(allowed, storageSize.Size)
+		}
+	}
+	if len(allowed) == 0 || Contains[int64](allowed, storage.ValueInt64()) {
+		return
+	}
+	sort.Slice(allowed, func(i, j int) bool { return allowed[i] < allowed[j] })
+	values := make([]string, len(allowed))
+	for i, size := range allowed {
+		values[i] = fmt.Sprint(size)
+	}
+	diags.AddAttributeError(path.Root("storage"),
+		"Invalid storage value",
+		fmt.Sprintf("The %d is an invalid value for the %q provider. Allowed values: %s", storage.ValueInt64(), provider, strings.Join(values, ", ")))
+}
+
+// validateCatalogValue adds an attribute error listing the allowed values when a known value isn't allowed.
+// It returns false when the error is added.
+func validateCatalogValue(diags *diag.Diagnostics, attribute path.Path, value types.String, allowed []string, scope string) bool {
+	if !isKnown(value) || Contains[string](allowed, value.ValueString()) {
+		return true
+	}
+	diags.AddAttributeError(attribute,
+		fmt.Sprintf("Invalid %s value", attribute.String()),
+		fmt.Sprintf("The %q is an invalid value%s. Allowed values: %s", value.ValueString(), scope, strings.Join(uniqueSorted(allowed), ", ")))
+	return false
+}
+
+func addCatalogWarning(diags *diag.Diagnostics, catalog string, err error) {
+	diags.AddWarning("Unable to validate the service against the SkySQL catalog",
+		fmt.Sprintf("Unable to read the %s, got error: %s", catalog, errorDetail(err)))
+}
+
+// changed reports whether the planned value is known and differs from the prior value.
+func changed(value types.String, prior types.String) bool {
+	return isKnown(value) && value.ValueString() != prior.ValueString()
+}
+
+// isKnown reports whether the value is set and known.
+func isKnown(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown() && value.ValueString() != ""
+}
+
+// isKnownInt64 reports whether the value is set and known.
+func isKnownInt64(value types.Int64) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+func uniqueSorted(values []string) []string {
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !Contains[string](unique, value) {
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceResourceValidateCatalog(t *testing.T) {
+	server := skysqltest.NewServer()
+	defer server.Close()
+	r := &ServiceResource{client: skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))}
+
+	newPlan := func() *ServiceResourceModel {
+		return &ServiceResourceModel{
+			ServiceType:      types.StringValue("transactional"),
+			Topology:         types.StringValue("es-single"),
+			Provider:         types.StringValue("gcp"),
+			Region:           types.StringValue("us-central1"),
+			Architecture:     types.StringValue("amd64"),
+			Size:             types.StringValue("sky-2x8"),
+			Version:          types.StringValue("10.6.11-6-1"),
+			MaxscaleSize:     types.StringNull(),
+			AvailabilityZone: types.StringValue("us-central1-a"),
+			Storage:          types.Int64Value(100),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		configure func(plan *ServiceResourceModel)
+		attribute string
+		detail    string
+	}{
+		{
+			name:      "valid service",
+			configure: func(plan *ServiceResourceModel) {},
+		},
+		{
+			name:      "unknown topology",
+			configure: func(plan *ServiceResourceModel) { plan.Topology = types.StringValue("galera") },
+			attribute: "topology",
+			detail:    `The "galera" is an invalid value. Allowed values: csdw, es-replica, es-single, sa, xpand`,
+		},
+		{
+			name:      "service type of another topology",
+			configure: func(plan *ServiceResourceModel) { plan.ServiceType = types.StringValue("analytical") },
+			attribute: "service_type",
+			detail:    `The "es-single" topology requires service_type = "transactional"`,
+		},
+		{
+			name:      "unknown service type",
+			configure: func(plan *ServiceResourceModel) { plan.ServiceType = types.StringValue("operational") },
+			attribute: "service_type",
+			detail:    `The "operational" is an invalid value. Allowed values: analytical, transactional`,
+		},
+		{
+			name:      "unknown architecture",
+			configure: func(plan *ServiceResourceModel) { plan.Architecture = types.StringValue("ppc64le") },
+			attribute: "architecture",
+			detail:    `The "ppc64le" is an invalid value for the "gcp" provider. Allowed values: amd64, arm64`,
+		},
+		{
+			name:      "unknown storage size",
+			configure: func(plan *ServiceResourceModel) { plan.Storage = types.Int64Value(150) },
+			attribute: "storage",
+			detail:    `The 150 is an invalid value for the "gcp" provider. Allowed values: 100, 200, 300, 400, 500, 1000, 2000, 5000, 10000`,
+		},
+		{
+			name:      "region of another provider",
+			configure: func(plan *ServiceResourceModel) { plan.Region = types.StringValue("us-east-1") },
+			attribute: "region",
+			detail:    `The "us-east-1" is an invalid value for the "gcp" provider. Allowed values: europe-west1, us-central1`,
+		},
+		{
+			name:      "unknown version",
+			configure: func(plan *ServiceResourceModel) { plan.Version = types.StringValue("8.0.23") },
+			attribute: "version",
+			detail:    `The "8.0.23" is an invalid value for the "es-single" topology. Allowed values: 10.6.11-6-1, 10.6.12-8-1`,
+		},
+		{
+			name:      "unknown size",
+			configure: func(plan *ServiceResourceModel) { plan.Size = types.StringValue("sky-64x256") },
+			attribute: "size",
+			detail:    `The "sky-64x256" is an invalid value for the "es-single" topology in the "gcp" provider. Allowed values: sky-2x4, sky-2x8, sky-4x16, sky-8x32`,
+		},
+		{
+			name:      "server size used for maxscale",
+			configure: func(plan *ServiceResourceModel) { plan.MaxscaleSize = types.StringValue("sky-8x32") },
+			attribute: "maxscale_size",
+			detail:    `The "sky-8x32" is an invalid value for the "es-single" topology in the "gcp" provider. Allowed values: sky-2x4, sky-4x16`,
+		},
+		{
+			name:      "zone of another region",
+			configure: func(plan *ServiceResourceModel) { plan.AvailabilityZone = types.StringValue("us-east-1a") },
+			attribute: "availability_zone",
+			detail:    `The "us-east-1a" is an invalid value in the "us-central1" region. Allowed values: us-central1-a, us-central1-b`,
+		},
+		{
+			name: "unknown values are not validated",
+			configure: func(plan *ServiceResourceModel) {
+				plan.Region = types.StringUnknown()
+				plan.Version = types.StringUnknown()
+				plan.AvailabilityZone = types.StringUnknown()
+				plan.Storage = types.Int64Unknown()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := newPlan()
+			test.configure(plan)
+			var diags diag.Diagnostics
+			r.validateCatalog(context.Background(), plan, nil, &diags)
+			if test.attribute == "" {
+				require.False(t, diags.HasError(), "%v", diags)
+				return
+			}
+			require.Len(t, diags.Errors(), 1)
+			require.Equal(t, test.detail, diags.Errors()[0].Detail())
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+			require.Equal(t, test.attribute, withPath.Path().String())
+		})
+	}
+
+	t.Run("only changed attributes are validated", func(t *testing.T) {
+		state := newPlan()
+		plan := newPlan()
+		requests := len(server.Requests())
+		var diags diag.Diagnostics
+		r.validateCatalog(context.Background(), plan, state, &diags)
+		require.False(t, diags.HasError())
+		require.Len(t, server.Requests(), requests)
+	})
+
+	t.Run("catalog errors are warnings", func(t *testing.T) {
+		server.InjectFault(skysqltest.Fault{Path: "/provisioning/v1/topologies", StatusCode: http.StatusInternalServerError})
+		defer server.ClearFaults()
+		var diags diag.Diagnostics
+		r.validateCatalog(context.Background(), newPlan(), nil, &diags)
+		require.False(t, diags.HasError())
+		require.Len(t, diags.Warnings(), 1)
+	})
+
+	t.Run("validation can be skipped", func(t *testing.T) {
+		plan := newPlan()
+		plan.Topology = types.StringValue("galera")
+		var diags diag.Diagnostics
+		(&ServiceResource{client: r.client, skipCatalogValidation: true}).validateCatalog(context.Background(), plan, nil, &diags)
+		require.Empty(t, diags)
+	})
+}
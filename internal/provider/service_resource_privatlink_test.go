@@ -26,7 +26,7 @@ func TestServiceResourcePrivateLink(t *testing.T) {
 
 	r := require.New(t)
 
-	configureOnce.Reset()
+	configuredClients.Reset()
 	var service *provisioning.Service
 	// Check API connectivity
 	expectRequest(func(w http.ResponseWriter, req *http.Request) {
@@ -336,7 +336,7 @@ func TestServiceResourcePrivateConnectWhenAllowedAccountsEmpty(t *testing.T) {
 
 	r := require.New(t)
 
-	configureOnce.Reset()
+	configuredClients.Reset()
 	// Check API connectivity
 	expectRequest(func(w http.ResponseWriter, req *http.Request) {
 		r.Equal(http.MethodGet, req.Method)
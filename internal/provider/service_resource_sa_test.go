@@ -27,7 +27,7 @@ func TestServiceResourceServerlessAnalytics(t *testing.T) {
 
 	r := require.New(t)
 
-	configureOnce.Reset()
+	configuredClients.Reset()
 	// Check API connectivity
 	expectRequest(func(w http.ResponseWriter, req *http.Request) {
 		r.Equal(http.MethodGet, req.Method)
@@ -330,7 +330,7 @@ func TestServiceResourceServerlessAnalytics(t *testing.T) {
 				  deletion_protection = false
 			}
 			  `,
-				ExpectError: regexp.MustCompile(`Cannot change service version`),
+				ExpectError: regexp.MustCompile(`Attempt to modify read-only attribute`),
 				Destroy:     false,
 			},
 		},
@@ -26,7 +26,7 @@ func TestServiceResourceScaleTest(t *testing.T) {
 
 	r := require.New(t)
 
-	configureOnce.Reset()
+	configuredClients.Reset()
 	var service *provisioning.Service
 	// Check API connectivity
 	expectRequest(func(w http.ResponseWriter, req *http.Request) {
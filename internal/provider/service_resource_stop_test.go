@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+)
+
+// TestServiceResourceStop checks that the endpoints and outbound IPs read back after stopping the service
+// replace the ones kept in the state.
+func TestServiceResourceStop(t *testing.T) {
+	server := skysqltest.NewServer(skysqltest.WithAccessToken("[token]"))
+	defer server.Close()
+	os.Setenv("TF_SKYSQL_API_ACCESS_TOKEN", "[token]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", server.URL)
+
+	configuredClients.Reset()
+
+	const config = `
+resource "skysql_service" default {
+  service_type   = "transactional"
+  topology       = "es-single"
+  cloud_provider = "gcp"
+  region         = "us-central1"
+  name           = "test-gcp"
+  architecture   = "amd64"
+  nodes          = 1
+  size           = "sky-2x8"
+  storage        = 100
+  ssl_enabled    = true
+  version        = "10.6.11-6-1"
+  is_active      = %t
+  wait_for_creation = true
+  wait_for_deletion = true
+  wait_for_update   = true
+  deletion_protection = false
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, true),
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "endpoints.#", "1"),
+					resource.TestCheckResourceAttr("skysql_service.default", "outbound_ips.#", "1"),
+				}...),
+			},
+			{
+				Config: fmt.Sprintf(config, false),
+				Check: resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+					resource.TestCheckResourceAttr("skysql_service.default", "is_active", "false"),
+					resource.TestCheckResourceAttr("skysql_service.default", "endpoints.#", "1"),
+					resource.TestCheckResourceAttr("skysql_service.default", "outbound_ips.#", "0"),
+				}...),
+			},
+		},
+	})
+}
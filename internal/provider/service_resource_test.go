@@ -31,6 +31,9 @@ func mockSkySQLAPI(t *testing.T) (string, func(http.HandlerFunc), func()) {
 		r                 = require.New(t)
 	)
 
+	// The mock only serves the expected requests, so the plan can't read the catalog.
+	t.Setenv("TF_SKYSQL_SKIP_CATALOG_VALIDATION", "true")
+
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		reqDump, err := httputil.DumpRequest(req, true)
 		if err != nil {
@@ -97,7 +100,7 @@ resource "skysql_service" default {
 }
 	            `,
 			before: func(r *require.Assertions) {
-				configureOnce.Reset()
+				configuredClients.Reset()
 				var service *provisioning.Service
 				expectRequest(func(w http.ResponseWriter, req *http.Request) {
 					r.Equal(http.MethodGet, req.Method)
@@ -172,6 +175,7 @@ resource "skysql_service" default {
 						r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
 						w.Header().Set("Content-Type", "application/json")
 						service.Status = "ready"
+						service.OutboundIps = []string{"203.0.113.10"}
 						r.NoError(json.NewEncoder(w).Encode(service))
 						w.WriteHeader(http.StatusOK)
 					})
@@ -194,6 +198,12 @@ resource "skysql_service" default {
 			},
 			checks: []resource.TestCheckFunc{
 				resource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
+				resource.TestCheckResourceAttr("skysql_service.default", "endpoints.#", "1"),
+				resource.TestCheckResourceAttr("skysql_service.default", "endpoints.0.name", "primary"),
+				resource.TestCheckResourceAttr("skysql_service.default", "endpoints.0.ports.0.name", "readwrite"),
+				resource.TestCheckResourceAttr("skysql_service.default", "endpoints.0.ports.0.port", "3306"),
+				resource.TestCheckResourceAttr("skysql_service.default", "outbound_ips.#", "1"),
+				resource.TestCheckResourceAttr("skysql_service.default", "outbound_ips.0", "203.0.113.10"),
 			},
 		},
 		{
@@ -217,7 +227,7 @@ resource "skysql_service" default {
 				}
 					            `,
 			before: func(r *require.Assertions) {
-				configureOnce.Reset()
+				configuredClients.Reset()
 				expectRequest(func(w http.ResponseWriter, req *http.Request) {
 					r.Equal(http.MethodGet, req.Method)
 					r.Equal("/provisioning/v1/versions", req.URL.Path)
@@ -250,6 +260,10 @@ resource "skysql_service" default {
 		{
 			name: "create service when skysql api returns unexpected error",
 			testResource: `
+				provider "skysql" {
+				 max_retries = 0
+				}
+
 				resource "skysql_service" default {
 				 service_type   = "transactional"
 				 topology       = "es-single"
@@ -268,7 +282,7 @@ resource "skysql_service" default {
 				}
 					            `,
 			before: func(r *require.Assertions) {
-				configureOnce.Reset()
+				configuredClients.Reset()
 				expectRequest(func(w http.ResponseWriter, req *http.Request) {
 					r.Equal(http.MethodGet, req.Method)
 					r.Equal("/provisioning/v1/versions", req.URL.Path)
@@ -280,15 +294,122 @@ resource "skysql_service" default {
 				expectRequest(func(w http.ResponseWriter, req *http.Request) {
 					r.Equal(http.MethodPost, req.Method)
 					r.Equal("/provisioning/v1/services", req.URL.Path)
+					r.NotEmpty(req.Header.Get(skysql.IdempotencyKeyHeader))
 					w.Header().Set("Content-Type", "application/json")
 					w.WriteHeader(http.StatusInternalServerError)
 				})
+				expectRequest(func(w http.ResponseWriter, req *http.Request) {
+					r.Equal(http.MethodGet, req.Method)
+					r.Equal("/provisioning/v1/services", req.URL.Path)
+					r.Equal("test-gcp", req.URL.Query().Get("name"))
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode([]provisioning.Service{})
+				})
 			},
 			checks: []resource.TestCheckFunc{
 				resource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
 			},
 			expectError: regexp.MustCompile(`Error creating service`),
 		},
+		{
+			name: "create service adopts the service when the create response is lost",
+			testResource: `
+				provider "skysql" {
+				 max_retries = 0
+				}
+
+				resource "skysql_service" default {
+				 service_type   = "transactional"
+				 topology       = "es-single"
+				 cloud_provider = "gcp"
+				 region         = "us-central1"
+				 name           = "test-gcp"
+				 architecture   = "amd64"
+				 nodes          = 1
+				 size           = "sky-2x8"
+				 storage        = 100
+				 ssl_enabled    = true
+				 version        = "10.6.11-6-1"
+				 wait_for_creation = true
+				 wait_for_deletion = true
+				 deletion_protection = false
+				}
+					            `,
+			before: func(r *require.Assertions) {
+				configuredClients.Reset()
+				service := &provisioning.Service{
+					ID:           serviceID,
+					Name:         "test-gcp",
+					Region:       "us-central1",
+					Provider:     "gcp",
+					Tier:         "foundation",
+					Topology:     "es-single",
+					Version:      "10.6.11-6-1",
+					Architecture: "amd64",
+					Size:         "sky-2x8",
+					Nodes:        1,
+					SSLEnabled:   true,
+					Status:       "ready",
+					CreatedOn:    int(time.Now().Unix()),
+					IsActive:     true,
+					ServiceType:  "transactional",
+				}
+				service.StorageVolume.Size = 100
+				service.StorageVolume.VolumeType = "pd-ssd"
+				expectRequest(func(w http.ResponseWriter, req *http.Request) {
+					r.Equal(http.MethodGet, req.Method)
+					r.Equal("/provisioning/v1/versions", req.URL.Path)
+					r.Equal("page_size=1", req.URL.RawQuery)
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode([]provisioning.Version{})
+				})
+				expectRequest(func(w http.ResponseWriter, req *http.Request) {
+					r.Equal(http.MethodPost, req.Method)
+					r.Equal("/provisioning/v1/services", req.URL.Path)
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusGatewayTimeout)
+					json.NewEncoder(w).Encode(&skysql.ErrorResponse{
+						Code: http.StatusGatewayTimeout,
+					})
+				})
+				expectRequest(func(w http.ResponseWriter, req *http.Request) {
+					r.Equal(http.MethodGet, req.Method)
+					r.Equal("/provisioning/v1/services", req.URL.Path)
+					r.Equal("test-gcp", req.URL.Query().Get("name"))
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode([]provisioning.Service{*service})
+				})
+				for i := 0; i <= 2; i++ {
+					expectRequest(func(w http.ResponseWriter, req *http.Request) {
+						r.Equal(http.MethodGet, req.Method)
+						r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
+						w.Header().Set("Content-Type", "application/json")
+						w.WriteHeader(http.StatusOK)
+						r.NoError(json.NewEncoder(w).Encode(service))
+					})
+				}
+				expectRequest(func(w http.ResponseWriter, req *http.Request) {
+					r.Equal(http.MethodDelete, req.Method)
+					r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
+					w.WriteHeader(http.StatusAccepted)
+				})
+				expectRequest(func(w http.ResponseWriter, req *http.Request) {
+					r.Equal(http.MethodGet, req.Method)
+					r.Equal("/provisioning/v1/services/"+serviceID, req.URL.Path)
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusNotFound)
+					json.NewEncoder(w).Encode(&skysql.ErrorResponse{
+						Code: http.StatusNotFound,
+					})
+				})
+			},
+			checks: []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr("skysql_service.default", "id", serviceID),
+			},
+		},
 		{
 			name: "create service with allowlist",
 			testResource: `
@@ -316,7 +437,7 @@ resource "skysql_service" default {
 		}
 			            `,
 			before: func(r *require.Assertions) {
-				configureOnce.Reset()
+				configuredClients.Reset()
 				var service *provisioning.Service
 				expectRequest(func(w http.ResponseWriter, req *http.Request) {
 					r.Equal(http.MethodGet, req.Method)
@@ -470,7 +591,7 @@ resource "skysql_service" default {
 		}
 			            `,
 			before: func(r *require.Assertions) {
-				configureOnce.Reset()
+				configuredClients.Reset()
 				var service *provisioning.Service
 				expectRequest(func(w http.ResponseWriter, req *http.Request) {
 					r.Equal(http.MethodGet, req.Method)
@@ -587,7 +708,7 @@ resource "skysql_service" default {
 				}
 					            `, GenerateServiceName(t)),
 			before: func(r *require.Assertions) {
-				configureOnce.Reset()
+				configuredClients.Reset()
 				expectRequest(func(w http.ResponseWriter, req *http.Request) {
 					r.Equal(http.MethodGet, req.Method)
 					r.Equal("/provisioning/v1/versions", req.URL.Path)
@@ -621,7 +742,7 @@ resource "skysql_service" default {
 				}
 					            `, GenerateServiceName(t)),
 			before: func(r *require.Assertions) {
-				configureOnce.Reset()
+				configuredClients.Reset()
 				expectRequest(func(w http.ResponseWriter, req *http.Request) {
 					r.Equal(http.MethodGet, req.Method)
 					r.Equal("/provisioning/v1/versions", req.URL.Path)
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+	"github.com/stretchr/testify/require"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestServiceResourceVersionUpgrade(t *testing.T) {
+	var versions []provisioning.Version
+	for _, name := range []string{"10.6.11-6-1", "10.6.12-8-1", "10.11.4-2-1", "10.11.5-3-1"} {
+		versions = append(versions, provisioning.Version{
+			Id:       "es-single-" + name,
+			Name:     name,
+			Version:  name,
+			Topology: "es-single",
+			Product:  "server",
+		})
+	}
+	server := skysqltest.NewServer(skysqltest.WithAccessToken("[token]"), skysqltest.WithVersions(versions...))
+	defer server.Close()
+	os.Setenv("TF_SKYSQL_API_ACCESS_TOKEN", "[token]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", server.URL)
+
+	configuredClients.Reset()
+
+	config := func(version string, allowMajor bool, isActive bool) string {
+		return fmt.Sprintf(`
+resource "skysql_service" default {
+  service_type   = "transactional"
+  topology       = "es-single"
+  cloud_provider = "gcp"
+  region         = "us-central1"
+  name           = "test-upgrade"
+  architecture   = "amd64"
+  nodes          = 1
+  size           = "sky-2x8"
+  storage        = 100
+  ssl_enabled    = true
+  version        = %q
+  allow_major_version_upgrade = %t
+  is_active      = %t
+  wait_for_creation   = true
+  wait_for_deletion   = true
+  wait_for_update     = true
+  deletion_protection = false
+}
+`, version, allowMajor, isActive)
+	}
+
+	serviceVersion := func(expected string) resource.TestCheckFunc {
+		return func(state *terraform.State) error {
+			services := server.Services()
+			require.Len(t, services, 1)
+			require.Equal(t, expected, services[0].Version)
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("10.6.11-6-1", false, true),
+				Check:  resource.TestCheckResourceAttr("skysql_service.default", "version", "10.6.11-6-1"),
+			},
+			{
+				Config: config("10.6.12-8-1", false, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_service.default", "version", "10.6.12-8-1"),
+					serviceVersion("10.6.12-8-1"),
+				),
+			},
+			{
+				Config:      config("10.6.11-6-1", false, true),
+				ExpectError: regexp.MustCompile(`downgrading the service`),
+			},
+			{
+				Config:      config("10.11.4-2-1", false, true),
+				ExpectError: regexp.MustCompile(`major version upgrade`),
+			},
+			{
+				Config: config("10.11.4-2-1", true, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_service.default", "version", "10.11.4-2-1"),
+					serviceVersion("10.11.4-2-1"),
+				),
+			},
+			{
+				// The service is stopped before it is upgraded, so the upgrade settles on stopped
+				Config: config("10.11.5-3-1", false, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("skysql_service.default", "version", "10.11.5-3-1"),
+					resource.TestCheckResourceAttr("skysql_service.default", "is_active", "false"),
+					serviceVersion("10.11.5-3-1"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateVersionUpgrade(t *testing.T) {
+	versions := []provisioning.Version{
+		{Name: "10.6.11-6-1", Topology: "es-single"},
+		{Name: "10.6.12-8-1", Topology: "es-single"},
+		{Name: "10.11.4-2-1", Topology: "es-single"},
+		{Name: "23.09.1", Topology: "xpand"},
+	}
+
+	require.NoError(t, validateVersionUpgrade(versions, "es-single", "10.6.11-6-1", "10.6.12-8-1", false))
+	require.NoError(t, validateVersionUpgrade(versions, "es-single", "10.6.11-6-1", "10.11.4-2-1", true))
+
+	err := validateVersionUpgrade(versions, "es-single", "10.6.11-6-1", "10.11.4-2-1", false)
+	require.ErrorContains(t, err, "major version upgrade")
+
+	err = validateVersionUpgrade(versions, "es-single", "10.6.12-8-1", "10.6.11-6-1", true)
+	require.ErrorContains(t, err, "downgrading the service")
+
+	err = validateVersionUpgrade(versions, "es-single", "10.6.11-6-1", "23.09.1", true)
+	require.ErrorContains(t, err, `not available for the "es-single" topology`)
+}
+
+func TestCompareVersions(t *testing.T) {
+	require.Equal(t, 0, compareVersions("10.6.11-6-1", "10.6.11-6-1"))
+	require.Equal(t, -1, compareVersions("10.6.11-6-1", "10.6.11-6-2"))
+	require.Equal(t, 1, compareVersions("10.11.4-2-1", "10.6.12-8-1"))
+	require.Equal(t, -1, compareVersions("10.6", "10.6.1"))
+	require.Equal(t, "10.11", majorVersion("10.11.4-2-1"))
+}
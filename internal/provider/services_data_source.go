@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ServicesDataSource{}
+
+func NewServicesDataSource() datasource.DataSource {
+	return &ServicesDataSource{}
+}
+
+// ServicesDataSource defines the data source implementation.
+type ServicesDataSource struct {
+	client skysql.API
+}
+
+// ServicesDataSourceModel describes the data source data model.
+type ServicesDataSourceModel struct {
+	ProjectID types.String             `tfsdk:"project_id"`
+	Name      types.String             `tfsdk:"name"`
+	NameRegex types.String             `tfsdk:"name_regex"`
+	Topology  types.String             `tfsdk:"topology"`
+	Provider  types.String             `tfsdk:"cloud_provider"`
+	Region    types.String             `tfsdk:"region"`
+	Status    types.String             `tfsdk:"status"`
+	IsActive  types.Bool               `tfsdk:"is_active"`
+	Services  []ServiceDataSourceModel `tfsdk:"services"`
+}
+
+func (d *ServicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_services"
+}
+
+func (d *ServicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieve the list of services, optionally filtered by their attributes.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the services of the project with this ID.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the services with this name.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the services with a name that matches this regular expression.",
+			},
+			"topology": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the services with this topology. Possible values are: es-single, es-replica, xpand, csdw and sa",
+			},
+			"cloud_provider": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the services deployed in this cloud provider. Possible values are: aws or gcp",
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the services deployed in this region.",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the services with this status, e.g. ready.",
+			},
+			"is_active": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return the services that are active (true) or stopped (false).",
+			},
+			"services": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The list of services that match all the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: serviceDataSourceAttributes(map[string]schema.Attribute{
+						"service_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the service",
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+func (d *ServicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(skysql.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ServicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ServicesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+	}
+
+	services, err := d.client.ListServices(ctx, func(values url.Values) {
+		if !state.ProjectID.IsNull() {
+			values.Set("project_id", state.ProjectID.ValueString())
+		}
+		if !state.Name.IsNull() {
+			values.Set("name", state.Name.ValueString())
+		}
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read SkySQL services", errorDetail(err))
+		return
+	}
+
+	state.Services = make([]ServiceDataSourceModel, 0)
+	for i := range services {
+		if !state.matches(&services[i], nameRegex) {
+			continue
+		}
+		var service ServiceDataSourceModel
+		service.fromService(&services[i])
+		state.Services = append(state.Services, service)
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// matches reports whether the service matches all the filters that are set.
+func (state *ServicesDataSourceModel) matches(service *provisioning.Service, nameRegex *regexp.Regexp) bool {
+	for _, filter := range []struct {
+		value  types.String
+		actual string
+	}{
+		{state.Name, service.Name},
+		{state.Topology, service.Topology},
+		{state.Provider, service.Provider},
+		{state.Region, service.Region},
+		{state.Status, service.Status},
+	} {
+		if !filter.value.IsNull() && filter.value.ValueString() != filter.actual {
+			return false
+		}
+	}
+	// The project is also filtered by the API, which may omit the project ID of the services
+	if !state.ProjectID.IsNull() && service.ProjectID != "" && state.ProjectID.ValueString() != service.ProjectID {
+		return false
+	}
+	if nameRegex != nil && !nameRegex.MatchString(service.Name) {
+		return false
+	}
+	if !state.IsActive.IsNull() && state.IsActive.ValueBool() != service.IsActive {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServicesDataSourceFilters(t *testing.T) {
+	service := &provisioning.Service{
+		Name:      "prod-orders",
+		ProjectID: "project-1",
+		Provider:  "gcp",
+		Region:    "us-central1",
+		Topology:  "es-single",
+		Status:    "ready",
+		IsActive:  true,
+	}
+
+	newState := func() *ServicesDataSourceModel {
+		return &ServicesDataSourceModel{
+			ProjectID: types.StringNull(),
+			Name:      types.StringNull(),
+			NameRegex: types.StringNull(),
+			Topology:  types.StringNull(),
+			Provider:  types.StringNull(),
+			Region:    types.StringNull(),
+			Status:    types.StringNull(),
+			IsActive:  types.BoolNull(),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		configure func(state *ServicesDataSourceModel)
+		nameRegex *regexp.Regexp
+		expected  bool
+	}{
+		{
+			name:      "no filters",
+			configure: func(state *ServicesDataSourceModel) {},
+			expected:  true,
+		},
+		{
+			name: "all filters match",
+			configure: func(state *ServicesDataSourceModel) {
+				state.ProjectID = types.StringValue("project-1")
+				state.Name = types.StringValue("prod-orders")
+				state.Topology = types.StringValue("es-single")
+				state.Provider = types.StringValue("gcp")
+				state.Region = types.StringValue("us-central1")
+				state.Status = types.StringValue("ready")
+				state.IsActive = types.BoolValue(true)
+			},
+			nameRegex: regexp.MustCompile("^prod-"),
+			expected:  true,
+		},
+		{
+			name: "other project",
+			configure: func(state *ServicesDataSourceModel) {
+				state.ProjectID = types.StringValue("project-2")
+			},
+		},
+		{
+			name: "other region",
+			configure: func(state *ServicesDataSourceModel) {
+				state.Region = types.StringValue("us-east1")
+			},
+		},
+		{
+			name:      "name does not match the regex",
+			configure: func(state *ServicesDataSourceModel) {},
+			nameRegex: regexp.MustCompile("^staging-"),
+		},
+		{
+			name: "stopped services only",
+			configure: func(state *ServicesDataSourceModel) {
+				state.IsActive = types.BoolValue(false)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := newState()
+			test.configure(state)
+			require.Equal(t, test.expected, state.matches(service, test.nameRegex))
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &SizesDataSource{}
+
+func NewSizesDataSource() datasource.DataSource {
+	return &SizesDataSource{}
+}
+
+// SizesDataSource defines the data source implementation.
+type SizesDataSource struct {
+	client skysql.API
+}
+
+// SizesDataSourceModel describes the data source data model.
+type SizesDataSourceModel struct {
+	Provider     types.String `tfsdk:"cloud_provider"`
+	Architecture types.String `tfsdk:"architecture"`
+	Topology     types.String `tfsdk:"topology"`
+	ServiceType  types.String `tfsdk:"service_type"`
+	Type         types.String `tfsdk:"type"`
+	Sizes        []SizeModel  `tfsdk:"sizes"`
+}
+
+type SizeModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	DisplayName  types.String `tfsdk:"display_name"`
+	Provider     types.String `tfsdk:"cloud_provider"`
+	Architecture types.String `tfsdk:"architecture"`
+	ServiceType  types.String `tfsdk:"service_type"`
+	Tier         types.String `tfsdk:"tier"`
+	Type         types.String `tfsdk:"type"`
+	CPU          types.Int64  `tfsdk:"cpu"`
+	Memory       types.Int64  `tfsdk:"memory"`
+	IsDefault    types.Bool   `tfsdk:"is_default"`
+}
+
+func (d *SizesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sizes"
+}
+
+func (d *SizesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieve the instance sizes available for the services and their MaxScale nodes. " +
+			"The sizes are sorted from the smallest to the largest.",
+		Attributes: map[string]schema.Attribute{
+			"cloud_provider": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the sizes available in this cloud provider. Valid values are: aws or gcp",
+			},
+			"architecture": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the sizes available for this architecture. Valid values are: amd64 or arm64",
+			},
+			"topology": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the sizes available for this topology. Valid values are: es-single, es-replica, xpand, csdw and sa",
+			},
+			"service_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the sizes available for this service type. Valid values are: analytical or transactional",
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the sizes of the database servers (server) or of the MaxScale nodes (maxscale).",
+				Validators: []validator.String{
+					stringvalidator.OneOf("server", "maxscale"),
+				},
+			},
+			"sizes": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The list of sizes that match all the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the size",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the size, e.g. sky-2x8. Use it as the size of a service",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The display name of the size",
+						},
+						"cloud_provider": schema.StringAttribute{
+							Computed:    true,
+							Description: "The cloud provider of the size",
+						},
+						"architecture": schema.StringAttribute{
+							Computed:    true,
+							Description: "The architecture of the size",
+						},
+						"service_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The service type of the size",
+						},
+						"tier": schema.StringAttribute{
+							Computed:    true,
+							Description: "The tier of the size",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the size: server or maxscale",
+						},
+						"cpu": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of virtual CPUs",
+						},
+						"memory": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The amount of memory in GB",
+						},
+						"is_default": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the size is the default size",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SizesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(skysql.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SizesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state SizesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sizes, err := d.client.GetSizes(ctx, func(values url.Values) {
+		for key, value := range map[string]types.String{
+			"provider":     state.Provider,
+			"architecture": state.Architecture,
+			"topology":     state.Topology,
+			"service_type": state.ServiceType,
+			"type":         state.Type,
+		} {
+			if !value.IsNull() {
+				values.Set(key, value.ValueString())
+			}
+		}
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read SkySQL sizes", errorDetail(err))
+		return
+	}
+
+	// The API may ignore some of the filters, so they are applied to the result as well
+	sizes = sizeFilter{
+		provider:     state.Provider.ValueString(),
+		architecture: state.Architecture.ValueString(),
+		topology:     state.Topology.ValueString(),
+		serviceType:  state.ServiceType.ValueString(),
+		sizeType:     state.Type.ValueString(),
+	}.apply(sizes)
+
+	state.Sizes = make([]SizeModel, 0, len(sizes))
+	for i := range sizes {
+		state.Sizes = append(state.Sizes, newSizeModel(&sizes[i]))
+	}
+	sort.SliceStable(state.Sizes, func(i, j int) bool {
+		if state.Sizes[i].CPU.ValueInt64() != state.Sizes[j].CPU.ValueInt64() {
+			return state.Sizes[i].CPU.ValueInt64() < state.Sizes[j].CPU.ValueInt64()
+		}
+		return state.Sizes[i].Memory.ValueInt64() < state.Sizes[j].Memory.ValueInt64()
+	})
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// sizeFilter selects the sizes matching the filters of the data source. An empty filter matches every size.
+type sizeFilter struct {
+	provider     string
+	architecture string
+	topology     string
+	serviceType  string
+	sizeType     string
+}
+
+// apply returns the sizes that match the filter. A size that doesn't list its topologies
+// matches every topology.
+func (f sizeFilter) apply(sizes []provisioning.Size) []provisioning.Size {
+	result := make([]provisioning.Size, 0, len(sizes))
+	for _, size := range sizes {
+		if (f.provider != "" && size.Provider != f.provider) ||
+			(f.architecture != "" && size.Architecture != f.architecture) ||
+			(f.serviceType != "" && size.ServiceType != f.serviceType) ||
+			(f.sizeType != "" && size.Type != f.sizeType) {
+			continue
+		}
+		if f.topology != "" && len(size.Topologies) > 0 && !Contains[string](size.Topologies, f.topology) {
+			continue
+		}
+		result = append(result, size)
+	}
+	return result
+}
+
+func newSizeModel(size *provisioning.Size) SizeModel {
+	return SizeModel{
+		ID:           types.StringValue(size.ID),
+		Name:         types.StringValue(size.Name),
+		DisplayName:  types.StringValue(size.DisplayName),
+		Provider:     types.StringValue(size.Provider),
+		Architecture: types.StringValue(size.Architecture),
+		ServiceType:  types.StringValue(size.ServiceType),
+		Tier:         types.StringValue(size.Tier),
+		Type:         types.StringValue(size.Type),
+		CPU:          parseQuantity(size.CPU),
+		Memory:       parseQuantity(size.RAM),
+		IsDefault:    types.BoolValue(size.Default),
+	}
+}
+
+// parseQuantity returns the number a quantity like "2 vCPU" or "8 GB" starts with,
+// or null when the quantity has no number.
+func parseQuantity(quantity string) types.Int64 {
+	fields := strings.Fields(quantity)
+	if len(fields) == 0 {
+		return types.Int64Null()
+	}
+	value, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
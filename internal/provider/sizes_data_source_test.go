@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizesDataSource(t *testing.T) {
+	server := skysqltest.NewServer(skysqltest.WithAccessToken("[token]"))
+	defer server.Close()
+	os.Setenv("TF_SKYSQL_API_ACCESS_TOKEN", "[token]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", server.URL)
+
+	configuredClients.Reset()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "skysql_sizes" "default" {
+  cloud_provider = "gcp"
+  architecture   = "amd64"
+  topology       = "es-single"
+  type           = "server"
+}
+
+data "skysql_sizes" "serverless" {
+  cloud_provider = "gcp"
+  topology       = "sa"
+}
+
+output "size" {
+  value = [for size in data.skysql_sizes.default.sizes : size.name if size.memory >= 16][0]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.skysql_sizes.default", "sizes.#", "4"),
+					resource.TestCheckResourceAttr("data.skysql_sizes.default", "sizes.0.name", "sky-2x4"),
+					resource.TestCheckResourceAttr("data.skysql_sizes.default", "sizes.0.cpu", "2"),
+					resource.TestCheckResourceAttr("data.skysql_sizes.default", "sizes.1.is_default", "true"),
+					resource.TestCheckOutput("size", "sky-4x16"),
+					resource.TestCheckResourceAttr("data.skysql_sizes.serverless", "sizes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestSizeFilter(t *testing.T) {
+	sizes := []provisioning.Size{
+		{Name: "sky-2x8", Provider: "gcp", Architecture: "amd64", Type: "server", Topologies: []string{"es-single", "es-replica"}},
+		{Name: "sky-2x4", Provider: "gcp", Architecture: "amd64", Type: "maxscale", Topologies: []string{"es-replica"}},
+		{Name: "sky-4x16", Provider: "gcp", Architecture: "arm64", Type: "server"},
+		{Name: "sky-2x8", Provider: "aws", Architecture: "amd64", Type: "server"},
+	}
+	names := func(sizes []provisioning.Size) []string {
+		result := make([]string, 0, len(sizes))
+		for _, size := range sizes {
+			result = append(result, size.Provider+"/"+size.Architecture+"/"+size.Type+"/"+size.Name)
+		}
+		return result
+	}
+
+	require.Len(t, sizeFilter{}.apply(sizes), 4)
+	require.Equal(t, []string{"gcp/amd64/server/sky-2x8", "gcp/arm64/server/sky-4x16"},
+		names(sizeFilter{provider: "gcp", topology: "es-single"}.apply(sizes)), "a size without topologies matches every topology")
+	require.Equal(t, []string{"gcp/amd64/maxscale/sky-2x4"},
+		names(sizeFilter{architecture: "amd64", sizeType: "maxscale"}.apply(sizes)))
+	require.Empty(t, sizeFilter{serviceType: "analytical"}.apply(sizes))
+}
+
+func TestNewSizeModel(t *testing.T) {
+	size := newSizeModel(&provisioning.Size{Name: "sky-4x16", CPU: "4 vCPU", RAM: "16 GB", Type: "server", Default: true})
+	require.Equal(t, types.Int64Value(4), size.CPU)
+	require.Equal(t, types.Int64Value(16), size.Memory)
+	require.Equal(t, types.BoolValue(true), size.IsDefault)
+
+	require.True(t, parseQuantity("").IsNull())
+	require.True(t, parseQuantity("unknown").IsNull())
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+)
+
+// tokenCommandTimeout is how long the token command can run.
+const tokenCommandTimeout = time.Minute
+
+// tokenCommandOutput is the JSON document the token command prints on its standard output.
+type tokenCommandOutput struct {
+	AccessToken string `json:"access_token"`
+	// ExpiresAt is an RFC3339 timestamp. When it is missing, the token is used until the API rejects it.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// commandTokenSource returns the tokens printed by an external program, e.g. a CI job that
+// exchanges its identity for a short-lived SkySQL API token.
+func commandTokenSource(command []string) skysql.TokenSource {
+	return skysql.TokenSourceFunc(func(ctx context.Context) (*skysql.Token, error) {
+		ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+		defer cancel()
+
+		tflog.Debug(ctx, "Running the token command", map[string]interface{}{
+			"command": command[0],
+		})
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && stderr.Len() > 0 {
+				return nil, fmt.Errorf("token command %q failed: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+			}
+			return nil, fmt.Errorf("token command %q failed: %w", command[0], err)
+		}
+
+		return parseTokenCommandOutput(stdout.Bytes())
+	})
+}
+
+func parseTokenCommandOutput(data []byte) (*skysql.Token, error) {
+	var output tokenCommandOutput
+	// The output is not included in the errors, because it may contain the token
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, errors.New("the token command output is not a JSON document with access_token and expires_at")
+	}
+	if output.AccessToken == "" {
+		return nil, errors.New("the token command output has no access_token")
+	}
+
+	token := &skysql.Token{AccessToken: output.AccessToken}
+	if output.ExpiresAt != nil {
+		token.ExpiresAt = *output.ExpiresAt
+	}
+	return token, nil
+}
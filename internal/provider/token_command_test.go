@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandTokenSource(t *testing.T) {
+	ctx := context.Background()
+
+	token, err := commandTokenSource([]string{"sh", "-c", `echo '{"access_token": "short-lived", "expires_at": "2023-03-01T12:00:00Z"}'`}).Token(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &skysql.Token{
+		AccessToken: "short-lived",
+		ExpiresAt:   time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC),
+	}, token)
+
+	token, err = commandTokenSource([]string{"echo", `{"access_token": "no-expiry"}`}).Token(ctx)
+	require.NoError(t, err)
+	require.True(t, token.ExpiresAt.IsZero())
+
+	_, err = commandTokenSource([]string{"sh", "-c", "echo 'not logged in' >&2; exit 1"}).Token(ctx)
+	require.ErrorContains(t, err, `token command "sh" failed: exit status 1: not logged in`)
+
+	_, err = commandTokenSource([]string{"echo", "secret-token"}).Token(ctx)
+	require.EqualError(t, err, "the token command output is not a JSON document with access_token and expires_at")
+
+	_, err = commandTokenSource([]string{"echo", `{"expires_at": "2023-03-01T12:00:00Z"}`}).Token(ctx)
+	require.EqualError(t, err, "the token command output has no access_token")
+}
+
+func TestClientRegistryTokenCommand(t *testing.T) {
+	server := skysqltest.NewServer(skysqltest.WithAccessToken("short-lived"))
+	defer server.Close()
+
+	client, err := newClientRegistry().client(context.Background(), clientConfig{
+		BaseURL:      server.URL,
+		TokenCommand: []string{"echo", `{"access_token": "short-lived"}`},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, client)
+
+	config := clientConfig{BaseURL: server.URL, TokenCommand: []string{"get-token", "--org", "staging"}}
+	other := config
+	other.TokenCommand = []string{"get-token", "--org", "production"}
+	require.NotEqual(t, config.key(), other.key())
+	require.NotContains(t, config.key(), "staging")
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &TopologiesDataSource{}
+
+var serviceTypes = []string{"analytical", "transactional"}
+
+func NewTopologiesDataSource() datasource.DataSource {
+	return &TopologiesDataSource{}
+}
+
+// TopologiesDataSource defines the data source implementation.
+type TopologiesDataSource struct {
+	client skysql.API
+}
+
+// TopologiesDataSourceModel describes the data source data model.
+type TopologiesDataSourceModel struct {
+	ServiceType types.String    `tfsdk:"service_type"`
+	Topologies  []TopologyModel `tfsdk:"topologies"`
+}
+
+type TopologyModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	DisplayName         types.String `tfsdk:"display_name"`
+	ServiceType         types.String `tfsdk:"service_type"`
+	SupportsMaxscale    types.Bool   `tfsdk:"supports_maxscale"`
+	SupportsReplication types.Bool   `tfsdk:"supports_replication"`
+	SupportsNoSQL       types.Bool   `tfsdk:"supports_nosql"`
+}
+
+func (d *TopologiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_topologies"
+}
+
+func (d *TopologiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieve the list of topologies the services can be created with and the features they support.",
+		Attributes: map[string]schema.Attribute{
+			"service_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the topologies of this service type. Valid values are: analytical or transactional",
+				Validators: []validator.String{
+					stringvalidator.OneOf(serviceTypes...),
+				},
+			},
+			"topologies": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The list of topologies.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the topology",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the topology, e.g. es-single. Use it as the topology of a service",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The display name of the topology",
+						},
+						"service_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The service type of the topology: analytical or transactional",
+						},
+						"supports_maxscale": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the services of the topology can have MaxScale nodes",
+						},
+						"supports_replication": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the services of the topology support replication",
+						},
+						"supports_nosql": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the services of the topology support the NoSQL interface",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TopologiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(skysql.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TopologiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state TopologiesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	topologies, err := d.client.GetTopologies(ctx, func(values url.Values) {
+		if !state.ServiceType.IsNull() {
+			values.Set("service_type", state.ServiceType.ValueString())
+		}
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read SkySQL topologies", errorDetail(err))
+		return
+	}
+
+	state.Topologies = make([]TopologyModel, 0, len(topologies))
+	for _, topology := range topologies {
+		state.Topologies = append(state.Topologies, TopologyModel{
+			ID:                  types.StringValue(topology.ID),
+			Name:                types.StringValue(topology.Name),
+			DisplayName:         types.StringValue(topology.DisplayName),
+			ServiceType:         types.StringValue(topology.ServiceType),
+			SupportsMaxscale:    types.BoolValue(topology.SupportsMaxscale),
+			SupportsReplication: types.BoolValue(topology.SupportsReplication),
+			SupportsNoSQL:       types.BoolValue(topology.SupportsNoSQL),
+		})
+	}
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultTracesFile is the file the spans are written to by the file exporter, unless TF_SKYSQL_OTEL_TRACES_FILE is set.
+const defaultTracesFile = "skysql-traces.jsonl"
+
+// SetupTracing installs the OpenTelemetry tracer provider selected by the standard environment variables.
+// Tracing is off unless OTEL_TRACES_EXPORTER is set to one of:
+//
+//   - console: the spans are written to the standard error, which Terraform includes in its logs
+//   - file: the spans are written to the file of TF_SKYSQL_OTEL_TRACES_FILE, skysql-traces.jsonl by default
+//
+// OTEL_SDK_DISABLED turns tracing off, and OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES describe the resource.
+// The returned function flushes the spans and closes the exporter. Tracing is optional, so when it can't be set up,
+// e.g. for an unsupported exporter like otlp, the error comes with a no-op function and tracing stays off.
+func SetupTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		return noop, nil
+	}
+
+	var output io.Writer
+	closeOutput := noop
+	switch exporter := strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER")); exporter {
+	case "", "none":
+		return noop, nil
+	case "console":
+		output = os.Stderr
+	case "file":
+		file, err := os.OpenFile(getEnv("TF_SKYSQL_OTEL_TRACES_FILE", defaultTracesFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return noop, fmt.Errorf("unable to open the traces file: %w", err)
+		}
+		output = file
+		closeOutput = func(context.Context) error { return file.Close() }
+	default:
+		return noop, fmt.Errorf("the %q traces exporter is not supported, use console or file", exporter)
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(output))
+	if err != nil {
+		return noop, err
+	}
+
+	res, err := resource.Merge(
+		resource.NewSchemaless(semconv.ServiceName("terraform-provider-skysql"), semconv.ServiceVersion(version)),
+		resource.Environment(),
+	)
+	if err != nil {
+		return noop, err
+	}
+
+	// The spans are exported synchronously, so none are lost when Terraform stops the provider
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		if err := provider.Shutdown(ctx); err != nil {
+			return err
+		}
+		return closeOutput(ctx)
+	}, nil
+}
+
+// startSpan starts a span of a provider operation, e.g. the creation of a service.
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(skysql.TracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// endSpan ends the span of a provider operation, with an error status when the operation reported an error.
+func endSpan(span trace.Span, diags diag.Diagnostics) {
+	for _, d := range diags.Errors() {
+		span.SetStatus(codes.Error, d.Summary())
+		span.AddEvent("error", trace.WithAttributes(attribute.String("summary", d.Summary()), attribute.String("detail", d.Detail())))
+	}
+	span.End()
+}
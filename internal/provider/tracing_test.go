@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a tracer provider that records the spans for the duration of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestOperationWaiterSpans(t *testing.T) {
+	recorder := recordSpans(t)
+	statuses := []string{"pending_create", "ready"}
+	refresh := func(ctx context.Context) (string, error) {
+		status := statuses[0]
+		statuses = statuses[1:]
+		return status, nil
+	}
+
+	w := newOperationWaiter(time.Second, []string{"ready"}, []string{"failed"})
+	w.PollInterval = time.Millisecond
+	w.MinBackoff = time.Millisecond
+	require.NoError(t, w.Wait(context.Background(), "dbpgf00000001", refresh))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	wait := spans[2]
+	require.Equal(t, "Wait for ready", wait.Name())
+	require.Equal(t, "dbpgf00000001", spanAttribute(wait, skysql.ServiceIDKey).AsString())
+
+	for i, status := range []string{"pending_create", "ready"} {
+		poll := spans[i]
+		require.Equal(t, "Poll status", poll.Name())
+		require.Equal(t, wait.SpanContext().SpanID(), poll.Parent().SpanID())
+		require.Equal(t, int64(i+1), spanAttribute(poll, skysql.AttemptKey).AsInt64())
+		require.Equal(t, status, spanAttribute(poll, skysql.StatusKey).AsString())
+	}
+}
+
+func TestEndSpan(t *testing.T) {
+	recorder := recordSpans(t)
+
+	_, span := startSpan(context.Background(), "skysql_service.Create")
+	var diags diag.Diagnostics
+	diags.AddError("Can not create service", "The API returns error")
+	endSpan(span, diags)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "Can not create service", spans[0].Status().Description)
+}
+
+func TestSetupTracing(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	ctx := context.Background()
+
+	t.Run("off by default", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_EXPORTER", "")
+		shutdown, err := SetupTracing(ctx, "test")
+		require.NoError(t, err)
+		require.NoError(t, shutdown(ctx))
+		require.Same(t, previous, otel.GetTracerProvider())
+	})
+
+	t.Run("file exporter", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "traces.jsonl")
+		t.Setenv("OTEL_TRACES_EXPORTER", "file")
+		t.Setenv("TF_SKYSQL_OTEL_TRACES_FILE", path)
+		shutdown, err := SetupTracing(ctx, "test")
+		require.NoError(t, err)
+
+		_, span := startSpan(ctx, "skysql_service.Read")
+		span.End()
+		require.NoError(t, shutdown(ctx))
+
+		traces, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Contains(t, string(traces), `"Name":"skysql_service.Read"`)
+		require.Contains(t, string(traces), "terraform-provider-skysql")
+	})
+
+	t.Run("unsupported exporter", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+		previous := otel.GetTracerProvider()
+		shutdown, err := SetupTracing(ctx, "test")
+		require.EqualError(t, err, `the "otlp" traces exporter is not supported, use console or file`)
+		require.NotNil(t, shutdown)
+		require.NoError(t, shutdown(ctx))
+		require.Equal(t, previous, otel.GetTracerProvider())
+	})
+}
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"github.com/asaskevich/govalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
 	"net"
+	"strings"
 )
 
 type allowListIPValidator struct{}
@@ -65,3 +68,27 @@ func Contains[T comparable](slice []T, value T) bool {
 }
 
 func toPtr[t any](u t) *t { return &u }
+
+// errorDetail returns the error message for a diagnostic. When the error comes from the SkySQL API,
+// the suggested solution and the trace ID are appended, so they can be used to open a support ticket.
+func errorDetail(err error) string {
+	if err == nil {
+		return ""
+	}
+	var apiErr *skysql.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(err.Error())
+	for _, solution := range apiErr.Solutions() {
+		sb.WriteString("\nSolution: ")
+		sb.WriteString(solution)
+	}
+	if apiErr.TraceID() != "" {
+		sb.WriteString("\nTrace ID: ")
+		sb.WriteString(apiErr.TraceID())
+	}
+	return sb.String()
+}
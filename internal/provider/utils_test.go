@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorDetail(t *testing.T) {
+	require.Equal(t, "", errorDetail(nil))
+	require.Equal(t, "connection refused", errorDetail(errors.New("connection refused")))
+	require.Equal(t, "invalid size\nSolution: use sky-2x8\nTrace ID: a1b2c3", errorDetail(&skysql.APIError{
+		StatusCode: http.StatusBadRequest,
+		Response: skysql.ErrorResponse{
+			TraceID: "a1b2c3",
+			Errors:  []skysql.ErrorDetails{{Message: "invalid size", Solution: "use sky-2x8"}},
+		},
+	}))
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// versionConstraint is a single constraint of a version constraint string, e.g. ">= 10.6".
+type versionConstraint struct {
+	operator string
+	version  string
+}
+
+// versionConstraints are the comma separated constraints of a version constraint string, e.g. ">= 10.6, < 11".
+// A version matches when it matches every constraint.
+type versionConstraints []versionConstraint
+
+// versionOperators are the supported operators, the longest first so that ">=" isn't read as ">".
+var versionOperators = []string{">=", "<=", "!=", "~>", "=", ">", "<"}
+
+// parseVersionConstraints parses a version constraint string like ">= 10.6, < 11" or "~> 10.6".
+// A constraint without an operator is an equality, e.g. "10.6" is "= 10.6".
+func parseVersionConstraints(value string) (versionConstraints, error) {
+	constraints := make(versionConstraints, 0)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		constraint := versionConstraint{operator: "="}
+		for _, operator := range versionOperators {
+			if strings.HasPrefix(part, operator) {
+				constraint.operator = operator
+				part = strings.TrimPrefix(part, operator)
+				break
+			}
+		}
+		constraint.version = strings.TrimSpace(part)
+
+		components := versionComponents(constraint.version)
+		if len(components) == 0 {
+			return nil, fmt.Errorf("the constraint %q has no version", strings.TrimSpace(constraint.operator+" "+constraint.version))
+		}
+		if _, err := strconv.Atoi(components[0]); err != nil {
+			return nil, fmt.Errorf("the version %q of the constraint must start with a number, like 10.6", constraint.version)
+		}
+		if constraint.operator == "~>" && len(components) < 2 {
+			return nil, fmt.Errorf("the version %q of the ~> constraint must have a minor version, like 10.6", constraint.version)
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
+// check reports whether the version matches every constraint.
+func (c versionConstraints) check(version string) bool {
+	for _, constraint := range c {
+		if !constraint.check(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// check reports whether the version matches the constraint.
+// The equality operators only compare the components of the version that the constraint sets,
+// so "= 10.6" matches 10.6.11-6-1. The ordering operators compare the whole version with the
+// constraint padded with zeros, so "> 10.6" is "> 10.6.0" and matches 10.6.11-6-1, and
+// "<= 10.6" doesn't. The pessimistic operator "~> 10.6.11" matches the versions of the
+// 10.6 series from 10.6.11.
+func (c versionConstraint) check(version string) bool {
+	components := versionComponents(c.version)
+	versionParts := versionComponents(version)
+
+	switch c.operator {
+	case "=", "!=", "~>":
+		truncated := versionParts
+		if len(truncated) > len(components) {
+			truncated = truncated[:len(components)]
+		}
+		result := compareVersions(strings.Join(truncated, "."), strings.Join(components, "."))
+		switch c.operator {
+		case "=":
+			return result == 0
+		case "!=":
+			return result != 0
+		}
+		series := versionConstraint{operator: "=", version: strings.Join(components[:len(components)-1], ".")}
+		return result >= 0 && series.check(version)
+	}
+
+	padded := components
+	for len(padded) < len(versionParts) {
+		padded = append(padded, "0")
+	}
+	result := compareVersions(version, strings.Join(padded, "."))
+	switch c.operator {
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// validateVersionUpgrade checks that the service can be upgraded from one version to another.
+// The target version must be available for the topology of the service and must be newer than
+// the current one. Upgrading to another release series, e.g. from 10.6 to 10.11, is a major
+// upgrade and is only accepted when allowMajor is true.
+func validateVersionUpgrade(versions []provisioning.Version, topology string, from string, to string, allowMajor bool) error {
+	available := false
+	for _, version := range versions {
+		if version.Topology == topology && version.Name == to {
+			available = true
+			break
+		}
+	}
+	if !available {
+		return fmt.Errorf("version %q is not available for the %q topology", to, topology)
+	}
+
+	if compareVersions(to, from) < 0 {
+		return fmt.Errorf("downgrading the service from version %q to %q isn't supported", from, to)
+	}
+
+	if majorVersion(to) != majorVersion(from) && !allowMajor {
+		return fmt.Errorf("upgrading the service from version %q to %q is a major version upgrade. "+
+			"Set allow_major_version_upgrade to true to allow it", from, to)
+	}
+
+	return nil
+}
+
+// compareVersions compares two versions like 10.6.11-6-1 component by component.
+// The result is 0 if a == b, -1 if a < b, and +1 if a > b.
+func compareVersions(a string, b string) int {
+	as, bs := versionComponents(a), versionComponents(b)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y string
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if c := compareVersionComponents(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareVersionComponents(a string, b string) int {
+	x, errX := strconv.Atoi(a)
+	y, errY := strconv.Atoi(b)
+	if errX == nil && errY == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+func versionComponents(version string) []string {
+	return strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '-'
+	})
+}
+
+// majorVersion returns the release series of the version, e.g. 10.6 for 10.6.11-6-1.
+func majorVersion(version string) string {
+	components := versionComponents(version)
+	if len(components) > 2 {
+		components = components[:2]
+	}
+	return strings.Join(components, ".")
+}
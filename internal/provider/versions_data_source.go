@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -21,12 +25,17 @@ func NewVersionsDataSource() datasource.DataSource {
 
 // VersionsDataSource defines the data source implementation.
 type VersionsDataSource struct {
-	client *skysql.Client
+	client skysql.API
 }
 
 type VersionDataSourceDataSourceModel struct {
-	Topology types.String   `tfsdk:"topology"`
-	Versions []VersionModel `tfsdk:"versions"`
+	Topology          types.String   `tfsdk:"topology"`
+	Product           types.String   `tfsdk:"product"`
+	IsMajor           types.Bool     `tfsdk:"is_major"`
+	VersionConstraint types.String   `tfsdk:"version_constraint"`
+	Latest            types.Bool     `tfsdk:"latest"`
+	Versions          []VersionModel `tfsdk:"versions"`
+	MostRecent        *VersionModel  `tfsdk:"most_recent"`
 }
 
 type VersionModel struct {
@@ -47,54 +56,83 @@ func (d *VersionsDataSource) Metadata(ctx context.Context, req datasource.Metada
 
 func (d *VersionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "SkySQL server versions",
+		Description: "Retrieve the SkySQL server versions. The versions are sorted by topology and from the newest to the oldest.",
 		Attributes: map[string]schema.Attribute{
 			"topology": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the versions of the topology, e.g. es-single",
+			},
+			"product": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the versions of the product, e.g. server",
+			},
+			"is_major": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return the major versions when true, or the other versions when false",
+			},
+			"version_constraint": schema.StringAttribute{
 				Optional: true,
+				Description: "Only return the versions that match the constraint, e.g. \">= 10.6, < 11\" or \"~> 10.6\". " +
+					"The operators are =, !=, >, >=, <, <= and ~>. = and != only compare the components of the version set by the constraint, " +
+					"so \"= 10.6\" matches 10.6.11-6-1. The operators >, >=, < and <= pad the constraint with zeros, so \"> 10.6\" is \"> 10.6.0\"",
+			},
+			"latest": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return the newest version of each topology",
 			},
 			"versions": schema.ListNestedAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "The versions, sorted by topology and from the newest to the oldest",
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id": schema.StringAttribute{
-							Computed:    true,
-							Description: "The ID of the version",
-						},
-						"name": schema.StringAttribute{
-							Computed:    true,
-							Description: "The name of the version",
-						},
-						"version": schema.StringAttribute{
-							Computed:    true,
-							Description: "The version display name",
-						},
-						"topology": schema.StringAttribute{
-							Computed:    true,
-							Description: "The topology that uses the version",
-						},
-						"product": schema.StringAttribute{
-							Computed:    true,
-							Description: "The product that uses the version",
-						},
-						"display_name": schema.StringAttribute{
-							Computed:    true,
-							Description: "The display name of the version",
-						},
-						"is_major": schema.BoolAttribute{
-							Computed:    true,
-							Description: "Whether the version is a major version",
-						},
-						"release_date": schema.StringAttribute{
-							Computed:    true,
-							Description: "The release date of the version",
-						},
-						"release_notes_url": schema.StringAttribute{
-							Computed:    true,
-							Description: "The URL to the release notes of the version",
-						},
-					},
+					Attributes: versionAttributes(),
 				},
 			},
+			"most_recent": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The newest of the versions, null when no version matches the filters",
+				Attributes:  versionAttributes(),
+			},
+		},
+	}
+}
+
+func versionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the version",
+		},
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the version",
+		},
+		"version": schema.StringAttribute{
+			Computed:    true,
+			Description: "The version display name",
+		},
+		"topology": schema.StringAttribute{
+			Computed:    true,
+			Description: "The topology that uses the version",
+		},
+		"product": schema.StringAttribute{
+			Computed:    true,
+			Description: "The product that uses the version",
+		},
+		"display_name": schema.StringAttribute{
+			Computed:    true,
+			Description: "The display name of the version",
+		},
+		"is_major": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether the version is a major version",
+		},
+		"release_date": schema.StringAttribute{
+			Computed:    true,
+			Description: "The release date of the version, in RFC 3339 format",
+		},
+		"release_notes_url": schema.StringAttribute{
+			Computed:    true,
+			Description: "The URL to the release notes of the version",
 		},
 	}
 }
@@ -105,12 +143,12 @@ func (d *VersionsDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*skysql.Client)
+	client, ok := req.ProviderData.(skysql.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected skysql.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -138,23 +176,36 @@ func (d *VersionsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		}
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read SkySQL versions", err.Error())
+		resp.Diagnostics.AddError("Unable to Read SkySQL versions", errorDetail(err))
 		return
 	}
 
-	for _, version := range versions {
-		versionState := VersionModel{
-			Id:              types.StringValue(version.Id),
-			Name:            types.StringValue(version.Name),
-			Version:         types.StringValue(version.Version),
-			Topology:        types.StringValue(version.Topology),
-			Product:         types.StringValue(version.Product),
-			DisplayName:     types.StringValue(version.DisplayName),
-			IsMajor:         types.BoolValue(version.IsMajor),
-			ReleaseDate:     types.StringValue(version.ReleaseDate.String()),
-			ReleaseNotesUrl: types.StringValue(version.ReleaseNotesUrl),
+	filter := versionFilter{
+		product: state.Product.ValueString(),
+		isMajor: state.IsMajor,
+		latest:  state.Latest.ValueBool(),
+	}
+	if state.VersionConstraint.ValueString() != "" {
+		filter.constraints, err = parseVersionConstraints(state.VersionConstraint.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("version_constraint"),
+				"Invalid version_constraint value",
+				fmt.Sprintf("The %q is not a valid version constraint: %s", state.VersionConstraint.ValueString(), err),
+			)
+			return
 		}
-		state.Versions = append(state.Versions, versionState)
+	}
+
+	versions = filter.apply(versions)
+	state.Versions = make([]VersionModel, 0, len(versions))
+	for i := range versions {
+		state.Versions = append(state.Versions, newVersionModel(&versions[i]))
+	}
+	state.MostRecent = nil
+	if newest := mostRecentVersion(versions); newest != nil {
+		mostRecent := newVersionModel(newest)
+		state.MostRecent = &mostRecent
 	}
 
 	// Set state
@@ -164,3 +215,83 @@ func (d *VersionsDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 }
+
+// versionFilter selects the versions matching the filters of the data source that the API doesn't support.
+type versionFilter struct {
+	product     string
+	isMajor     types.Bool
+	constraints versionConstraints
+	latest      bool
+}
+
+// apply returns the versions that match the filter, sorted by topology and from the newest to the oldest.
+func (f versionFilter) apply(versions []provisioning.Version) []provisioning.Version {
+	result := make([]provisioning.Version, 0, len(versions))
+	for _, version := range versions {
+		if f.product != "" && version.Product != f.product {
+			continue
+		}
+		if !f.isMajor.IsNull() && version.IsMajor != f.isMajor.ValueBool() {
+			continue
+		}
+		if f.constraints != nil && !f.constraints.check(version.Name) {
+			continue
+		}
+		result = append(result, version)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Topology != result[j].Topology {
+			return result[i].Topology < result[j].Topology
+		}
+		return isNewerVersion(&result[i], &result[j])
+	})
+
+	if f.latest {
+		latest := make([]provisioning.Version, 0)
+		for _, version := range result {
+			if len(latest) == 0 || latest[len(latest)-1].Topology != version.Topology {
+				latest = append(latest, version)
+			}
+		}
+		result = latest
+	}
+	return result
+}
+
+// mostRecentVersion returns the newest of the versions, or nil when there are none.
+func mostRecentVersion(versions []provisioning.Version) *provisioning.Version {
+	var newest *provisioning.Version
+	for i := range versions {
+		if newest == nil || isNewerVersion(&versions[i], newest) {
+			newest = &versions[i]
+		}
+	}
+	return newest
+}
+
+// isNewerVersion reports whether a is newer than b, by version and then by release date.
+func isNewerVersion(a *provisioning.Version, b *provisioning.Version) bool {
+	if c := compareVersions(a.Name, b.Name); c != 0 {
+		return c > 0
+	}
+	return a.ReleaseDate.After(b.ReleaseDate)
+}
+
+func newVersionModel(version *provisioning.Version) VersionModel {
+	releaseDate := types.StringNull()
+	if !version.ReleaseDate.IsZero() {
+		releaseDate = types.StringValue(version.ReleaseDate.Format(time.RFC3339))
+	}
+	return VersionModel{
+		Id:              types.StringValue(version.Id),
+		Name:            types.StringValue(version.Name),
+		Version:         types.StringValue(version.Version),
+		Topology:        types.StringValue(version.Topology),
+		Product:         types.StringValue(version.Product),
+		DisplayName:     types.StringValue(version.DisplayName),
+		IsMajor:         types.BoolValue(version.IsMajor),
+		ReleaseDate:     releaseDate,
+		ReleaseNotesUrl: types.StringValue(version.ReleaseNotesUrl),
+	}
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionsDataSource(t *testing.T) {
+	server := skysqltest.NewServer(skysqltest.WithAccessToken("[token]"))
+	defer server.Close()
+	os.Setenv("TF_SKYSQL_API_ACCESS_TOKEN", "[token]")
+	os.Setenv("TF_SKYSQL_API_BASE_URL", server.URL)
+
+	configuredClients.Reset()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"skysql": providerserver.NewProtocol6WithError(New("")()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "skysql_versions" "default" {
+  topology           = "es-single"
+  product            = "server"
+  version_constraint = ">= 10.6, < 11"
+}
+
+data "skysql_versions" "latest" {
+  latest = true
+}
+
+data "skysql_versions" "none" {
+  version_constraint = "~> 11.0"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.skysql_versions.default", "versions.#", "2"),
+					resource.TestCheckResourceAttr("data.skysql_versions.default", "versions.0.name", "10.6.12-8-1"),
+					resource.TestCheckResourceAttr("data.skysql_versions.default", "versions.0.release_date", "2023-03-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("data.skysql_versions.default", "most_recent.name", "10.6.12-8-1"),
+					resource.TestCheckResourceAttr("data.skysql_versions.latest", "versions.#", "3"),
+					resource.TestCheckResourceAttr("data.skysql_versions.latest", "versions.0.topology", "es-replica"),
+					resource.TestCheckResourceAttr("data.skysql_versions.none", "versions.#", "0"),
+					resource.TestCheckNoResourceAttr("data.skysql_versions.none", "most_recent.name"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseVersionConstraints(t *testing.T) {
+	for constraint, expected := range map[string]map[string]bool{
+		">= 10.6, < 11": {"10.6.11-6-1": true, "10.11.2-1": true, "10.5.9-6-1": false, "11.0.1-1": false},
+		"~> 10.6":       {"10.6.11-6-1": true, "10.11.2-1": true, "11.0.1-1": false},
+		"~> 10.6.12":    {"10.6.11-6-1": false, "10.6.12-8-1": true, "10.6.13-1": true, "10.11.2-1": false},
+		"10.6":          {"10.6.11-6-1": true, "10.11.2-1": false},
+		"!= 10.6.11":    {"10.6.11-6-1": false, "10.6.12-8-1": true},
+		"> 10.6":        {"10.6.12-8-1": true, "10.5.9-6-1": false, "10.11.2-1": true},
+		"<= 10.6":       {"10.6.12-8-1": false, "10.6.0": true, "10.5.9-6-1": true},
+		"> 10.6.12":     {"10.6.12": false, "10.6.12-8-1": true, "10.6.13-1": true},
+		"< 11":          {"10.11.2-1": true, "11.0.1-1": false},
+	} {
+		constraints, err := parseVersionConstraints(constraint)
+		require.NoError(t, err, constraint)
+		for version, matches := range expected {
+			require.Equal(t, matches, constraints.check(version), "%s %s", version, constraint)
+		}
+	}
+
+	for _, constraint := range []string{"", ">=", ">= 10.6,", "latest", "~> 10"} {
+		_, err := parseVersionConstraints(constraint)
+		require.Error(t, err, constraint)
+	}
+}
+
+func TestVersionFilter(t *testing.T) {
+	releaseDate := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	versions := []provisioning.Version{
+		{Name: "10.6.11-6-1", Topology: "es-single", Product: "server", IsMajor: true, ReleaseDate: releaseDate},
+		{Name: "10.6.12-8-1", Topology: "es-single", Product: "server", ReleaseDate: releaseDate.AddDate(0, 1, 0)},
+		{Name: "23.09.1", Topology: "xpand", Product: "xpand", ReleaseDate: releaseDate},
+		{Name: "6.4.1", Topology: "xpand", Product: "maxscale", ReleaseDate: releaseDate},
+	}
+	names := func(versions []provisioning.Version) []string {
+		result := make([]string, 0, len(versions))
+		for _, version := range versions {
+			result = append(result, version.Name)
+		}
+		return result
+	}
+
+	require.Equal(t, []string{"10.6.12-8-1", "10.6.11-6-1", "23.09.1", "6.4.1"}, names(versionFilter{}.apply(versions)))
+	require.Equal(t, []string{"10.6.12-8-1", "10.6.11-6-1"}, names(versionFilter{product: "server"}.apply(versions)))
+	require.Equal(t, []string{"10.6.11-6-1"}, names(versionFilter{isMajor: types.BoolValue(true)}.apply(versions)))
+	require.Equal(t, []string{"10.6.12-8-1", "23.09.1"}, names(versionFilter{latest: true}.apply(versions)))
+
+	constraints, err := parseVersionConstraints("< 10.6.12")
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.6.11-6-1", "6.4.1"}, names(versionFilter{constraints: constraints}.apply(versions)))
+
+	require.Equal(t, "23.09.1", mostRecentVersion(versions).Name)
+	require.Nil(t, mostRecentVersion(nil))
+
+	require.True(t, newVersionModel(&provisioning.Version{}).ReleaseDate.IsNull())
+	require.Equal(t, types.StringValue("2023-03-01T00:00:00Z"), newVersionModel(&versions[0]).ReleaseDate)
+}
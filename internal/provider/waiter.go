@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const defaultPollInterval = 5 * time.Second
+const defaultMinBackoff = 1 * time.Second
+const defaultMaxBackoff = 30 * time.Second
+
+// serviceStatusDeleted is reported by serviceStatus once the service does not exist anymore.
+const serviceStatusDeleted = "deleted"
+
+// Keys of the timeouts block, see operationTimeout.
+const (
+	timeoutCreate = "create"
+	timeoutUpdate = "update"
+	timeoutDelete = "delete"
+)
+
+// operationTimeout returns the timeout of the operation set in the timeouts block, or the default when
+// the block doesn't set it. The timeouts module fails to parse a key that is missing from a block that sets
+// other keys, so an unset key is resolved here.
+func operationTimeout(ctx context.Context, value timeouts.Value, operation string, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultTimeout, nil
+	}
+	if attribute, ok := value.Attributes()[operation].(types.String); !ok || attribute.IsNull() || attribute.IsUnknown() {
+		return defaultTimeout, nil
+	}
+
+	switch operation {
+	case timeoutCreate:
+		return value.Create(ctx, defaultTimeout)
+	case timeoutUpdate:
+		return value.Update(ctx, defaultTimeout)
+	case timeoutDelete:
+		return value.Delete(ctx, defaultTimeout)
+	}
+	return defaultTimeout, nil
+}
+
+// statusFunc returns the current status of a long-running operation.
+type statusFunc func(ctx context.Context) (string, error)
+
+// operationWaiter polls the status of a long-running operation until it reaches a target or a failure state.
+// The first poll happens immediately. The wait between polls starts at PollInterval and doubles while the
+// status does not change, bounded by MinBackoff and MaxBackoff. A status change resets it to PollInterval.
+type operationWaiter struct {
+	Target       []string
+	Failure      []string
+	Timeout      time.Duration
+	PollInterval time.Duration
+	MinBackoff   time.Duration
+	MaxBackoff   time.Duration
+}
+
+func newOperationWaiter(timeout time.Duration, target []string, failure []string) *operationWaiter {
+	return &operationWaiter{
+		Target:       target,
+		Failure:      failure,
+		Timeout:      timeout,
+		PollInterval: defaultPollInterval,
+		MinBackoff:   defaultMinBackoff,
+		MaxBackoff:   defaultMaxBackoff,
+	}
+}
+
+// Wait polls the status of the operation on the object with the given ID until it reaches a target state.
+// It fails when the status reaches a failure state, when refresh fails or when the timeout expires.
+// The wait and each poll are traced with a span.
+func (w *operationWaiter) Wait(ctx context.Context, id string, refresh statusFunc) error {
+	ctx, span := startSpan(ctx, "Wait for "+strings.Join(w.Target, " or "), skysql.ServiceIDKey.String(id))
+	defer span.End()
+
+	err := w.wait(ctx, id, refresh)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (w *operationWaiter) wait(ctx context.Context, id string, refresh statusFunc) error {
+	start := time.Now()
+	waitCtx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+
+	lastStatus := ""
+	wait := w.PollInterval
+	for attempt := 1; ; attempt++ {
+		status, err := w.poll(waitCtx, id, attempt, refresh)
+		if err != nil {
+			if waitCtx.Err() != nil && ctx.Err() == nil {
+				return w.timeoutError(id, lastStatus, time.Since(start))
+			}
+			return err
+		}
+
+		if status != lastStatus {
+			tflog.Debug(ctx, "Operation status changed", map[string]interface{}{
+				"id":      id,
+				"from":    lastStatus,
+				"to":      status,
+				"attempt": attempt,
+				"elapsed": time.Since(start).Round(time.Second).String(),
+			})
+			lastStatus = status
+			wait = w.PollInterval
+		} else {
+			wait *= 2
+		}
+
+		if Contains[string](w.Failure, status) {
+			return fmt.Errorf("%s reached the %s status after %s", id, status, time.Since(start).Round(time.Second))
+		}
+		if Contains[string](w.Target, status) {
+			return nil
+		}
+
+		wait = w.backoff(wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return w.timeoutError(id, lastStatus, time.Since(start))
+		case <-timer.C:
+		}
+	}
+}
+
+// poll reads the status of the operation in a span.
+func (w *operationWaiter) poll(ctx context.Context, id string, attempt int, refresh statusFunc) (string, error) {
+	ctx, span := startSpan(ctx, "Poll status", skysql.ServiceIDKey.String(id), skysql.AttemptKey.Int(attempt))
+	defer span.End()
+
+	status, err := refresh(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+	span.SetAttributes(skysql.StatusKey.String(status))
+	return status, nil
+}
+
+func (w *operationWaiter) backoff(wait time.Duration) time.Duration {
+	if wait < w.MinBackoff {
+		wait = w.MinBackoff
+	}
+	if w.MaxBackoff > 0 && wait > w.MaxBackoff {
+		wait = w.MaxBackoff
+	}
+	return wait
+}
+
+func (w *operationWaiter) timeoutError(id string, lastStatus string, elapsed time.Duration) error {
+	if lastStatus == "" {
+		lastStatus = "unknown"
+	}
+	return fmt.Errorf(
+		"timeout while waiting for %s to reach the %s status: last status was %s after %s",
+		id, strings.Join(w.Target, " or "), lastStatus, elapsed.Round(time.Second))
+}
+
+// serviceStatus returns a statusFunc that reads the status of the service,
+// and reports serviceStatusDeleted once the service is not found.
+func serviceStatus(client skysql.API, serviceID string) statusFunc {
+	return func(ctx context.Context) (string, error) {
+		service, err := client.GetServiceByID(ctx, serviceID)
+		if err != nil {
+			if errors.Is(err, skysql.ErrorServiceNotFound) {
+				return serviceStatusDeleted, nil
+			}
+			return "", fmt.Errorf("error retrieving service details: %w", err)
+		}
+		return service.Status, nil
+	}
+}
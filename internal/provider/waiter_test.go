@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationWaiter(t *testing.T) {
+	statuses := func(values ...string) (statusFunc, *int) {
+		calls := 0
+		return func(ctx context.Context) (string, error) {
+			status := values[len(values)-1]
+			if calls < len(values) {
+				status = values[calls]
+			}
+			calls++
+			return status, nil
+		}, &calls
+	}
+	newWaiter := func(timeout time.Duration) *operationWaiter {
+		w := newOperationWaiter(timeout, []string{"ready", "stopped"}, []string{"failed"})
+		w.PollInterval = time.Millisecond
+		w.MinBackoff = time.Millisecond
+		w.MaxBackoff = 4 * time.Millisecond
+		return w
+	}
+
+	t.Run("reaches a target state", func(t *testing.T) {
+		refresh, calls := statuses("pending_create", "provisioning", "ready")
+		require.NoError(t, newWaiter(time.Second).Wait(context.Background(), "dbtest", refresh))
+		require.Equal(t, 3, *calls)
+	})
+
+	t.Run("stops on a failure state", func(t *testing.T) {
+		refresh, calls := statuses("provisioning", "failed", "ready")
+		err := newWaiter(time.Second).Wait(context.Background(), "dbtest", refresh)
+		require.ErrorContains(t, err, "dbtest reached the failed status")
+		require.Equal(t, 2, *calls)
+	})
+
+	t.Run("reports the last status on timeout", func(t *testing.T) {
+		refresh, _ := statuses("pending_create", "provisioning")
+		err := newWaiter(20*time.Millisecond).Wait(context.Background(), "dbtest", refresh)
+		require.ErrorContains(t, err, "timeout while waiting for dbtest to reach the ready or stopped status: last status was provisioning after")
+	})
+
+	t.Run("returns refresh errors", func(t *testing.T) {
+		refreshErr := errors.New("boom")
+		err := newWaiter(time.Second).Wait(context.Background(), "dbtest", func(ctx context.Context) (string, error) {
+			return "", refreshErr
+		})
+		require.ErrorIs(t, err, refreshErr)
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		refresh, _ := statuses("provisioning")
+		err := newWaiter(time.Second).Wait(ctx, "dbtest", refresh)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestOperationWaiterBackoff(t *testing.T) {
+	w := &operationWaiter{MinBackoff: time.Second, MaxBackoff: 30 * time.Second}
+	require.Equal(t, time.Second, w.backoff(100*time.Millisecond))
+	require.Equal(t, 10*time.Second, w.backoff(10*time.Second))
+	require.Equal(t, 30*time.Second, w.backoff(time.Minute))
+}
+
+func TestOperationTimeout(t *testing.T) {
+	ctx := context.Background()
+	attributeTypes := map[string]attr.Type{
+		timeoutCreate: types.StringType,
+		timeoutUpdate: types.StringType,
+		timeoutDelete: types.StringType,
+	}
+	// A timeouts block that only sets the update timeout
+	partial := timeouts.Value{Object: types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+		timeoutCreate: types.StringNull(),
+		timeoutUpdate: types.StringValue("90m"),
+		timeoutDelete: types.StringNull(),
+	})}
+
+	timeout, diags := operationTimeout(ctx, partial, timeoutUpdate, time.Hour)
+	require.False(t, diags.HasError())
+	require.Equal(t, 90*time.Minute, timeout)
+
+	for _, operation := range []string{timeoutCreate, timeoutDelete} {
+		timeout, diags = operationTimeout(ctx, partial, operation, time.Hour)
+		require.False(t, diags.HasError(), operation)
+		require.Equal(t, time.Hour, timeout, operation)
+	}
+
+	timeout, diags = operationTimeout(ctx, timeouts.Value{Object: types.ObjectNull(attributeTypes)}, timeoutDelete, time.Hour)
+	require.False(t, diags.HasError())
+	require.Equal(t, time.Hour, timeout)
+
+	invalid := timeouts.Value{Object: types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+		timeoutCreate: types.StringValue("soon"),
+		timeoutUpdate: types.StringNull(),
+		timeoutDelete: types.StringNull(),
+	})}
+	_, diags = operationTimeout(ctx, invalid, timeoutCreate, time.Hour)
+	require.True(t, diags.HasError())
+}
@@ -0,0 +1,60 @@
+package skysql
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/autonomous"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// Ensure Client satisfies the API interfaces
+var _ API = &Client{}
+
+// ProvisioningAPI manages services and reads the provisioning catalog.
+type ProvisioningAPI interface {
+	GetVersions(ctx context.Context, options ...func(url.Values)) ([]provisioning.Version, error)
+	GetSizes(ctx context.Context, options ...func(url.Values)) ([]provisioning.Size, error)
+	GetRegions(ctx context.Context, options ...func(url.Values)) ([]provisioning.Region, error)
+	GetTopologies(ctx context.Context, options ...func(url.Values)) ([]provisioning.Topology, error)
+	GetStorageSizes(ctx context.Context, options ...func(url.Values)) ([]provisioning.StorageSize, error)
+	GetAvailabilityZones(ctx context.Context, region string, options ...func(url.Values)) ([]provisioning.AvailabilityZone, error)
+	GetServiceByID(ctx context.Context, serviceID string) (*provisioning.Service, error)
+	ListServices(ctx context.Context, options ...func(url.Values)) ([]provisioning.Service, error)
+	FindServicesByName(ctx context.Context, name string, projectID string) ([]provisioning.Service, error)
+	CreateService(ctx context.Context, req *provisioning.CreateServiceRequest) (*provisioning.Service, error)
+	DeleteServiceByID(ctx context.Context, serviceID string) error
+	GetServiceCredentialsByID(ctx context.Context, serviceID string) (*provisioning.Credentials, error)
+	UpdateServiceAllowListByID(ctx context.Context, serviceID string, allowlist []provisioning.AllowListItem) ([]provisioning.AllowListItem, error)
+	ReadServiceAllowListByID(ctx context.Context, serviceID string) (provisioning.ReadAllowListResponse, error)
+	SetServicePowerState(ctx context.Context, serviceID string, isActive bool) error
+	ModifyServiceEndpoints(ctx context.Context, serviceID string, mechanism string, allowedAccounts []string, visibility string) (*provisioning.ServiceEndpoint, error)
+	ModifyServiceSize(ctx context.Context, serviceID string, size string) error
+	ModifyServiceNodeNumber(ctx context.Context, serviceID string, nodes int64) error
+	ModifyServiceStorage(ctx context.Context, serviceID string, size int64, iops int64) error
+	UpgradeServiceVersion(ctx context.Context, serviceID string, version string) error
+}
+
+// OrganizationAPI manages the organization projects.
+type OrganizationAPI interface {
+	GetProjects(ctx context.Context, options ...func(url.Values)) ([]organization.Project, error)
+	CreateProject(ctx context.Context, req *organization.CreateProjectRequest) (*organization.Project, error)
+	UpdateProject(ctx context.Context, projectID string, req *organization.UpdateProjectRequest) (*organization.Project, error)
+	DeleteProject(ctx context.Context, projectID string) error
+}
+
+// AutonomousAPI manages the autonomous scaling actions of services.
+type AutonomousAPI interface {
+	SetAutonomousActions(ctx context.Context, value autonomous.SetAutonomousActionsRequest) ([]autonomous.ActionResponse, error)
+	GetAutonomousActions(ctx context.Context, serviceID string) ([]autonomous.ActionResponse, error)
+	DeleteAutonomousAction(ctx context.Context, actionID string) error
+}
+
+// API is the SkySQL API used by the provider. Client implements it; wrap it to add
+// caching, auditing or dry-run behavior, or replace it with a fake in tests.
+type API interface {
+	ProvisioningAPI
+	OrganizationAPI
+	AutonomousAPI
+}
@@ -0,0 +1,98 @@
+package skysql
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultCatalogCacheTTL is how long the responses of the catalog endpoints are cached by default.
+const DefaultCatalogCacheTTL = 5 * time.Minute
+
+// catalogCache caches the responses of the read-only catalog endpoints, like the versions and the sizes.
+// Concurrent requests for the same key are deduplicated, so only one of them reaches the API.
+type catalogCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]catalogCacheEntry
+	group   singleflight.Group
+}
+
+type catalogCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// WithCatalogCache caches the responses of the catalog endpoints for the given time.
+// A ttl of zero or less turns the cache off.
+func WithCatalogCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		if ttl <= 0 {
+			c.catalog = nil
+			return
+		}
+		c.catalog = newCatalogCache(ttl)
+	}
+}
+
+func newCatalogCache(ttl time.Duration) *catalogCache {
+	return &catalogCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]catalogCacheEntry),
+	}
+}
+
+// cachedList returns the cached response for the key, or fetches it when it is missing or expired.
+// Errors are never cached. The returned slice is a copy, so callers can modify it.
+func cachedList[T any](cache *catalogCache, key string, fetch func() ([]T, error)) ([]T, error) {
+	if cache == nil {
+		return fetch()
+	}
+
+	if value, ok := cache.get(key); ok {
+		return append([]T(nil), value.([]T)...), nil
+	}
+
+	value, err, _ := cache.group.Do(key, func() (interface{}, error) {
+		// The value may have been stored while waiting for the previous call
+		if value, ok := cache.get(key); ok {
+			return value, nil
+		}
+		value, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		cache.set(key, value)
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]T(nil), value.([]T)...), nil
+}
+
+func (c *catalogCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *catalogCache) set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = catalogCacheEntry{value: value, expires: c.now().Add(c.ttl)}
+}
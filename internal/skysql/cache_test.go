@@ -0,0 +1,100 @@
+package skysql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogCache(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		if req.URL.Query().Get("topology") == "broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(&ErrorResponse{Code: http.StatusBadRequest})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]provisioning.Version{{Name: "10.6.11-6-1", Topology: req.URL.Query().Get("topology")}})
+	}))
+	defer ts.Close()
+
+	now := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	client := New(ts.URL, "[token]", WithRetryPolicy(0, 0), WithCatalogCache(time.Minute))
+	client.catalog.now = func() time.Time { return now }
+	ctx := context.Background()
+	byTopology := func(topology string) func(url.Values) {
+		return func(values url.Values) {
+			values.Set("topology", topology)
+		}
+	}
+
+	t.Run("concurrent requests are deduplicated", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				versions, err := client.GetVersions(ctx, byTopology("es-single"))
+				require.NoError(t, err)
+				require.Len(t, versions, 1)
+			}()
+		}
+		// Wait for the first request to reach the server before letting it respond
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("responses are cached by query", func(t *testing.T) {
+		versions, err := client.GetVersions(ctx, byTopology("es-single"))
+		require.NoError(t, err)
+		versions[0].Name = "modified"
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		versions, err = client.GetVersions(ctx, byTopology("es-single"))
+		require.NoError(t, err)
+		require.Equal(t, "10.6.11-6-1", versions[0].Name, "the cached response must not be modified by the callers")
+
+		_, err = client.GetVersions(ctx, byTopology("xpand"))
+		require.NoError(t, err)
+		require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("responses expire", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		_, err := client.GetVersions(ctx, byTopology("es-single"))
+		require.NoError(t, err)
+		require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := client.GetVersions(ctx, byTopology("broken"))
+			require.ErrorIs(t, err, ErrorValidation)
+		}
+		require.Equal(t, int32(5), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("cache can be turned off", func(t *testing.T) {
+		client := New(ts.URL, "[token]", WithRetryPolicy(0, 0), WithCatalogCache(0))
+		for i := 0; i < 2; i++ {
+			_, err := client.GetVersions(ctx, byTopology("es-single"))
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(7), atomic.LoadInt32(&calls))
+	})
+}
@@ -2,95 +2,127 @@ package skysql
 
 import (
 	"context"
-	"errors"
 	"github.com/go-resty/resty/v2"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/google/uuid"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/autonomous"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
 	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
-	"strconv"
-	"time"
 )
 
 type Client struct {
 	HTTPClient *resty.Client
+	catalog    *catalogCache
+	tokens     *tokenRefresher
+
+	maxListItems int
 }
 
-func New(baseURL string, AccessToken string) *Client {
-	transport := logging.NewLoggingHTTPTransport(http.DefaultTransport)
+// Option configures the Client created by New.
+type Option func(*Client)
 
-	clientName, _ := os.Executable()
+func New(baseURL string, AccessToken string, options ...Option) *Client {
+	transport := newTransport(http.DefaultTransport)
 
-	return &Client{
+	client := &Client{
 		HTTPClient: resty.NewWithClient(&http.Client{Transport: transport}).
-			SetHeader("User-Agent", filepath.Base(clientName)).
+			SetHeader("User-Agent", UserAgent("", "", "")).
 			SetAuthScheme("Bearer").
 			SetAuthToken(AccessToken).
 			SetBaseURL(baseURL).
-			// Set retry count too non-zero to enable retries
-			SetRetryCount(3).
-			// Default is 100 milliseconds.
-			SetRetryWaitTime(5 * time.Second).
-			// MaxWaitTime can be overridden as well.
-			// Default is 2 seconds.
-			SetRetryMaxWaitTime(20 * time.Second).
-			// SetRetryAfter sets callback to calculate wait time between retries.
-			// Default (nil) implies exponential backoff with jitter
-			SetRetryAfter(func(client *resty.Client, resp *resty.Response) (time.Duration, error) {
-				return 0, errors.New("retries quota exceeded")
-			}).
-			AddRetryCondition(
-				// RetryConditionFunc type is for retry condition function
-				// input: non-nil Response OR request execution error
-				func(r *resty.Response, err error) bool {
-					return r.StatusCode() == http.StatusInternalServerError
-				}).
+			SetRetryCount(DefaultRetryCount).
+			SetRetryWaitTime(DefaultRetryWaitTime).
+			SetRetryMaxWaitTime(DefaultRetryMaxWaitTime).
+			SetRetryAfter(retryAfter).
+			AddRetryCondition(shouldRetry).
+			OnBeforeRequest(withAttempt).
 			EnableTrace(),
+		maxListItems: DefaultMaxListItems,
+	}
+
+	for _, option := range options {
+		option(client)
 	}
+
+	return client
+}
+
+// GetProjects returns all the projects of the organization, reading every page of the list.
+func (c *Client) GetProjects(ctx context.Context, options ...func(url.Values)) ([]organization.Project, error) {
+	return listAll[organization.Project](ctx, c, "/organization/v1/projects", options...)
 }
 
-func (c *Client) GetProjects(ctx context.Context) ([]organization.Project, error) {
+// CreateProject creates a project. Like CreateService, the request carries an idempotency key,
+// so it can be retried safely.
+func (c *Client) CreateProject(ctx context.Context, req *organization.CreateProjectRequest) (*organization.Project, error) {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
-		SetResult([]organization.Project{}).
+		SetHeader(IdempotencyKeyHeader, uuid.NewString()).
+		SetBody(req).
+		SetResult(organization.Project{}).
 		SetError(&ErrorResponse{}).
 		SetContext(ctx).
-		Get("/organization/v1/projects")
+		Post("/organization/v1/projects")
+	if err != nil {
+		return nil, err
+	}
 	if resp.IsError() {
 		return nil, handleError(resp)
 	}
-	return *resp.Result().(*[]organization.Project), err
+	return resp.Result().(*organization.Project), err
 }
 
-func WithPageSize(value uint) func(url.Values) {
-	return func(values url.Values) {
-		values.Set("page_size", strconv.Itoa(int(value)))
+func (c *Client) UpdateProject(ctx context.Context, projectID string, req *organization.UpdateProjectRequest) (*organization.Project, error) {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetBody(req).
+		SetResult(organization.Project{}).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		Put("/organization/v1/projects/" + projectID)
+	if err != nil {
+		return nil, err
 	}
+	if resp.IsError() {
+		return nil, handleError(resp)
+	}
+	return resp.Result().(*organization.Project), err
 }
 
-func (c *Client) GetVersions(ctx context.Context, options ...func(url.Values)) ([]provisioning.Version, error) {
-	request := c.HTTPClient.R()
-	for _, option := range options {
-		option(request.QueryParam)
-	}
-	resp, err := request.
+func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
+	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
-		SetResult([]provisioning.Version{}).
 		SetError(&ErrorResponse{}).
 		SetContext(ctx).
-		Get("/provisioning/v1/versions")
+		Delete("/organization/v1/projects/" + projectID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
 	if resp.IsError() {
-		return nil, handleError(resp)
+		return handleError(resp)
 	}
-	return *resp.Result().(*[]provisioning.Version), err
+	return err
+}
+
+func (c *Client) GetVersions(ctx context.Context, options ...func(url.Values)) ([]provisioning.Version, error) {
+	return cachedListAll[provisioning.Version](ctx, c, "/provisioning/v1/versions", options...)
+}
+
+func (c *Client) GetSizes(ctx context.Context, options ...func(url.Values)) ([]provisioning.Size, error) {
+	return cachedListAll[provisioning.Size](ctx, c, "/provisioning/v1/sizes", options...)
+}
+
+func (c *Client) GetRegions(ctx context.Context, options ...func(url.Values)) ([]provisioning.Region, error) {
+	return cachedListAll[provisioning.Region](ctx, c, "/provisioning/v1/regions", options...)
+}
+
+func (c *Client) GetTopologies(ctx context.Context, options ...func(url.Values)) ([]provisioning.Topology, error) {
+	return cachedListAll[provisioning.Topology](ctx, c, "/provisioning/v1/topologies", options...)
+}
+
+func (c *Client) GetStorageSizes(ctx context.Context, options ...func(url.Values)) ([]provisioning.StorageSize, error) {
+	return cachedListAll[provisioning.StorageSize](ctx, c, "/provisioning/v1/storage-sizes", options...)
 }
 
 func (c *Client) GetServiceByID(ctx context.Context, serviceID string) (*provisioning.Service, error) {
@@ -109,9 +141,12 @@ func (c *Client) GetServiceByID(ctx context.Context, serviceID string) (*provisi
 	return resp.Result().(*provisioning.Service), err
 }
 
+// CreateService creates a new service. Each call sends its own idempotency key, so the request
+// can be safely retried without creating a second service.
 func (c *Client) CreateService(ctx context.Context, req *provisioning.CreateServiceRequest) (*provisioning.Service, error) {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
+		SetHeader(IdempotencyKeyHeader, uuid.NewString()).
 		SetResult(provisioning.Service{}).
 		SetError(&ErrorResponse{}).
 		SetContext(ctx).
@@ -128,6 +163,34 @@ func (c *Client) CreateService(ctx context.Context, req *provisioning.CreateServ
 	return resp.Result().(*provisioning.Service), err
 }
 
+// ListServices returns all the services, reading every page of the list.
+func (c *Client) ListServices(ctx context.Context, options ...func(url.Values)) ([]provisioning.Service, error) {
+	return listAll[provisioning.Service](ctx, c, "/provisioning/v1/services", options...)
+}
+
+// FindServicesByName returns the services with the given name.
+// When projectID is not empty, only services of that project are returned.
+func (c *Client) FindServicesByName(ctx context.Context, name string, projectID string) ([]provisioning.Service, error) {
+	result, err := c.ListServices(ctx, func(values url.Values) {
+		values.Set("name", name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]provisioning.Service, 0)
+	for _, service := range result {
+		if service.Name != name {
+			continue
+		}
+		if projectID != "" && service.ProjectID != projectID {
+			continue
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
 func (c *Client) DeleteServiceByID(ctx context.Context, serviceID string) error {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
@@ -201,20 +264,18 @@ func (c *Client) ReadServiceAllowListByID(ctx context.Context, serviceID string)
 }
 
 func handleError(resp *resty.Response) error {
-	if resp.StatusCode() == 404 {
-		return ErrorServiceNotFound
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode(),
+		Status:     resp.Status(),
 	}
-	if resp.StatusCode() == 401 {
-		return ErrorUnauthorized
+	if resp.RawResponse != nil && resp.RawResponse.Request != nil {
+		apiErr.Method = resp.RawResponse.Request.Method
+		apiErr.Path = resp.RawResponse.Request.URL.Path
 	}
-	if resp.Error() != nil {
-		if resp.StatusCode() == 500 {
-			return errors.New("SkySQL API returned 500 Internal Server Error")
-		}
-		errResp := resp.Error().(*ErrorResponse)
-		return errors.New(errResp.Errors[0].Message)
+	if errResp, ok := resp.Error().(*ErrorResponse); ok && errResp != nil {
+		apiErr.Response = *errResp
 	}
-	return errors.New(resp.Status())
+	return apiErr
 }
 
 func (c *Client) SetServicePowerState(ctx context.Context, serviceID string, isActive bool) error {
@@ -316,39 +377,34 @@ func (c *Client) ModifyServiceStorage(ctx context.Context, serviceID string, siz
 	return err
 }
 
-func (c *Client) SetAutonomousActions(
-	ctx context.Context,
-	value autonomous.SetAutonomousActionsRequest,
-) ([]autonomous.ActionResponse, error) {
+func (c *Client) UpgradeServiceVersion(ctx context.Context, serviceID string, version string) error {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
 		SetContext(ctx).
-		SetBody(value).
-		SetResult([]autonomous.ActionResponse{}).
+		SetBody(&provisioning.UpdateServiceVersionRequest{Version: version}).
 		SetError(&ErrorResponse{}).
-		Post("/als/v1/actions")
+		Post("/provisioning/v1/services/" + serviceID + "/version")
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if resp.IsError() {
-		return nil, handleError(resp)
+		return handleError(resp)
 	}
 
-	response := *resp.Result().(*[]autonomous.ActionResponse)
-	if response == nil {
-		response = make([]autonomous.ActionResponse, 0)
-	}
-	return response, err
+	return err
 }
 
-func (c *Client) GetAutonomousActions(ctx context.Context, serviceID string) ([]autonomous.ActionResponse, error) {
+func (c *Client) SetAutonomousActions(
+	ctx context.Context,
+	value autonomous.SetAutonomousActionsRequest,
+) ([]autonomous.ActionResponse, error) {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
 		SetContext(ctx).
+		SetBody(value).
 		SetResult([]autonomous.ActionResponse{}).
 		SetError(&ErrorResponse{}).
-		SetQueryParam("service_id", serviceID).
-		Get("/als/v1/actions")
+		Post("/als/v1/actions")
 	if err != nil {
 		return nil, err
 	}
@@ -363,6 +419,12 @@ func (c *Client) GetAutonomousActions(ctx context.Context, serviceID string) ([]
 	return response, err
 }
 
+func (c *Client) GetAutonomousActions(ctx context.Context, serviceID string) ([]autonomous.ActionResponse, error) {
+	return listAll[autonomous.ActionResponse](ctx, c, "/als/v1/actions", func(values url.Values) {
+		values.Set("service_id", serviceID)
+	})
+}
+
 func (c *Client) DeleteAutonomousAction(ctx context.Context, actionID string) error {
 	resp, err := c.HTTPClient.R().
 		SetHeader("Accept", "application/json").
@@ -380,22 +442,5 @@ func (c *Client) DeleteAutonomousAction(ctx context.Context, actionID string) er
 }
 
 func (c *Client) GetAvailabilityZones(ctx context.Context, region string, options ...func(url.Values)) ([]provisioning.AvailabilityZone, error) {
-	request := c.HTTPClient.R()
-	for _, option := range options {
-		option(request.QueryParam)
-	}
-	resp, err := request.
-		SetHeader("Accept", "application/json").
-		SetResult([]provisioning.AvailabilityZone{}).
-		SetError(&ErrorResponse{}).
-		SetContext(ctx).
-		Get("/provisioning/v1/regions/" + region + "/zones")
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsError() {
-		return nil, handleError(resp)
-	}
-	return *resp.Result().(*[]provisioning.AvailabilityZone), err
+	return cachedListAll[provisioning.AvailabilityZone](ctx, c, "/provisioning/v1/regions/"+region+"/zones", options...)
 }
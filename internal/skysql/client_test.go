@@ -0,0 +1,35 @@
+package skysql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindServicesByName(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]provisioning.Service{
+			{ID: "dbdgf42002418", Name: "orders-prod", ProjectID: "project-1"},
+			{ID: "dbdgf42002419", Name: "orders-prod", ProjectID: "project-2"},
+			{ID: "dbdgf42002420", Name: "orders-prod"},
+			{ID: "dbdgf42002421", Name: "orders-stage", ProjectID: "project-1"},
+		})
+	}))
+	t.Cleanup(ts.Close)
+	client := New(ts.URL, "token")
+
+	services, err := client.FindServicesByName(context.Background(), "orders-prod", "project-1")
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.Equal(t, "dbdgf42002418", services[0].ID)
+
+	services, err = client.FindServicesByName(context.Background(), "orders-prod", "")
+	require.NoError(t, err)
+	require.Len(t, services, 3)
+}
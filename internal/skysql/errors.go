@@ -1,6 +1,11 @@
 package skysql
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 // ErrorResponse struct
 type ErrorResponse struct {
@@ -21,6 +26,117 @@ type ErrorDetails struct {
 	Location string `json:"location,omitempty"`
 }
 
+// ErrorServiceNotFound is the class of the 404 Not Found errors of the service paths, see servicesPath.
 var ErrorServiceNotFound = errors.New("service not found")
 
+var ErrorNotFound = errors.New("skysql resource not found")
+
 var ErrorUnauthorized = errors.New("skysql returns unauthorized error")
+
+var ErrorConflict = errors.New("skysql returns conflict error")
+
+var ErrorQuotaExceeded = errors.New("skysql quota exceeded")
+
+var ErrorValidation = errors.New("skysql request validation failed")
+
+var ErrorRateLimited = errors.New("skysql rate limit exceeded")
+
+// servicesPath is the path of the services, and the prefix of the paths of a service.
+const servicesPath = "/provisioning/v1/services/"
+
+// APIError is returned by the client when the SkySQL API responds with an error status.
+// It keeps the whole error response, so callers can show the trace ID and the suggested
+// solution. Use errors.Is with the Error* sentinels above to check the class of the error.
+type APIError struct {
+	StatusCode int
+	Status     string
+	// Method and Path are the method and the URL path of the failed request.
+	Method   string
+	Path     string
+	Response ErrorResponse
+}
+
+func (e *APIError) Error() string {
+	messages := make([]string, 0, len(e.Response.Errors))
+	for _, detail := range e.Response.Errors {
+		if detail.Message != "" {
+			messages = append(messages, detail.Message)
+		} else if detail.Error != "" {
+			messages = append(messages, detail.Error)
+		}
+	}
+	if len(messages) > 0 {
+		return strings.Join(messages, "; ")
+	}
+
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		if e.Path == "" {
+			return ErrorNotFound.Error()
+		}
+		return fmt.Sprintf("not found: %s %s", e.Method, e.Path)
+	case http.StatusUnauthorized:
+		return ErrorUnauthorized.Error()
+	}
+
+	if e.Status != "" {
+		return fmt.Sprintf("SkySQL API returned %s", e.Status)
+	}
+	return fmt.Sprintf("SkySQL API returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is reports whether the error belongs to the class of the target sentinel error.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrorServiceNotFound:
+		return e.StatusCode == http.StatusNotFound && strings.Contains(e.Path, servicesPath)
+	case ErrorNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrorUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrorConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrorRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrorQuotaExceeded:
+		return e.isQuotaExceeded()
+	case ErrorValidation:
+		return (e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity) &&
+			!e.isQuotaExceeded()
+	}
+	return false
+}
+
+// TraceID returns the trace ID of the failed request, if the API provided one.
+func (e *APIError) TraceID() string {
+	return e.Response.TraceID
+}
+
+// Solutions returns the solutions suggested by the API for the failed request.
+func (e *APIError) Solutions() []string {
+	solutions := make([]string, 0)
+	for _, detail := range e.Response.Errors {
+		if detail.Solution != "" {
+			solutions = append(solutions, detail.Solution)
+		}
+	}
+	return solutions
+}
+
+func (e *APIError) isQuotaExceeded() bool {
+	if e.StatusCode == http.StatusPaymentRequired {
+		return true
+	}
+	if e.StatusCode != http.StatusForbidden && e.StatusCode != http.StatusBadRequest &&
+		e.StatusCode != http.StatusUnprocessableEntity {
+		return false
+	}
+	for _, detail := range e.Response.Errors {
+		for _, value := range []string{detail.Type, detail.Error, detail.Message} {
+			if strings.Contains(strings.ToLower(value), "quota") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package skysql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		payload     *ErrorResponse
+		expectClass error
+		expectError string
+	}{
+		{
+			name:        "not found without body",
+			statusCode:  http.StatusNotFound,
+			expectClass: ErrorServiceNotFound,
+			expectError: "not found: GET /provisioning/v1/services/dbdgf42002418",
+		},
+		{
+			name:        "unauthorized",
+			statusCode:  http.StatusUnauthorized,
+			expectClass: ErrorUnauthorized,
+			expectError: "skysql returns unauthorized error",
+		},
+		{
+			name:        "internal server error without errors",
+			statusCode:  http.StatusInternalServerError,
+			payload:     &ErrorResponse{Code: http.StatusInternalServerError},
+			expectError: "SkySQL API returned 500 Internal Server Error",
+		},
+		{
+			name:       "conflict",
+			statusCode: http.StatusConflict,
+			payload: &ErrorResponse{
+				Errors: []ErrorDetails{{Message: "service already exists"}},
+			},
+			expectClass: ErrorConflict,
+			expectError: "service already exists",
+		},
+		{
+			name:       "quota exceeded",
+			statusCode: http.StatusBadRequest,
+			payload: &ErrorResponse{
+				Errors: []ErrorDetails{{Type: "quota", Message: "too many services"}},
+			},
+			expectClass: ErrorQuotaExceeded,
+			expectError: "too many services",
+		},
+		{
+			name:       "validation",
+			statusCode: http.StatusUnprocessableEntity,
+			payload: &ErrorResponse{
+				Errors: []ErrorDetails{
+					{Message: "invalid size", Location: "size"},
+					{Message: "invalid region", Location: "region"},
+				},
+			},
+			expectClass: ErrorValidation,
+			expectError: "invalid size; invalid region",
+		},
+		{
+			name:        "rate limited",
+			statusCode:  http.StatusTooManyRequests,
+			expectClass: ErrorRateLimited,
+			expectError: "SkySQL API returned 429 Too Many Requests",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if test.payload == nil {
+					w.WriteHeader(test.statusCode)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(test.statusCode)
+				r.NoError(json.NewEncoder(w).Encode(test.payload))
+			}))
+			defer ts.Close()
+
+			client := New(ts.URL, "[token]", WithRetryPolicy(0, 0))
+
+			_, err := client.GetServiceByID(context.Background(), "dbdgf42002418")
+			r.Error(err)
+			r.Equal(test.expectError, err.Error())
+
+			var apiErr *APIError
+			r.True(errors.As(err, &apiErr))
+			r.Equal(test.statusCode, apiErr.StatusCode)
+
+			if test.expectClass != nil {
+				r.ErrorIs(err, test.expectClass)
+			}
+			for _, class := range []error{ErrorServiceNotFound, ErrorUnauthorized, ErrorConflict, ErrorQuotaExceeded, ErrorValidation, ErrorRateLimited} {
+				if class != test.expectClass {
+					r.False(errors.Is(err, class), "unexpected error class %q", class)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorNotFound(t *testing.T) {
+	r := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := New(ts.URL, "[token]", WithRetryPolicy(0, 0))
+
+	err := client.DeleteProject(context.Background(), "project-1")
+	r.EqualError(err, "not found: DELETE /organization/v1/projects/project-1")
+	r.ErrorIs(err, ErrorNotFound)
+	r.False(errors.Is(err, ErrorServiceNotFound), "only the service paths are services")
+}
+
+func TestAPIErrorSolutionAndTraceID(t *testing.T) {
+	r := require.New(t)
+	err := &APIError{
+		StatusCode: http.StatusBadRequest,
+		Response: ErrorResponse{
+			TraceID: "a1b2c3",
+			Errors: []ErrorDetails{
+				{Message: "size is not supported", Solution: "use sky-2x8"},
+			},
+		},
+	}
+
+	r.Equal("a1b2c3", err.TraceID())
+	r.Equal([]string{"use sky-2x8"}, err.Solutions())
+}
@@ -0,0 +1,161 @@
+package skysql
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+// RedactedValue replaces the secrets in the logs.
+const RedactedValue = "***"
+
+// sensitiveHeaders are the headers whose values are never logged.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// sensitiveKeys are the JSON keys whose values are never logged, compared case-insensitively.
+// Any key that contains "password" or "secret" is sensitive as well.
+var sensitiveKeys = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"token":         true,
+	"api_key":       true,
+	"apikey":        true,
+	"private_key":   true,
+}
+
+// redactingTransport logs the requests and responses at debug level, like the logging transport of
+// the plugin SDK and with the same fields, but with the secrets redacted: the Authorization header,
+// the passwords of the service credentials and the other sensitive JSON values.
+type redactingTransport struct {
+	transport http.RoundTripper
+}
+
+func newRedactingTransport(transport http.RoundTripper) *redactingTransport {
+	return &redactingTransport{transport: transport}
+}
+
+func (t *redactingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.SetField(req.Context(), logging.FieldHttpTransactionId, uuid.NewString())
+
+	fields := map[string]interface{}{
+		logging.FieldHttpOperationType:       logging.OperationHttpRequest,
+		logging.FieldHttpRequestMethod:       req.Method,
+		logging.FieldHttpRequestUri:          req.URL.RequestURI(),
+		logging.FieldHttpRequestProtoVersion: req.Proto,
+	}
+	addHeaderFields(fields, req.Header)
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		fields[logging.FieldHttpRequestBody] = redactBody(body)
+	}
+	tflog.Debug(ctx, "Sending HTTP Request", fields)
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fields = map[string]interface{}{
+		logging.FieldHttpOperationType:        logging.OperationHttpResponse,
+		logging.FieldHttpResponseProtoVersion: resp.Proto,
+		logging.FieldHttpResponseStatusCode:   resp.StatusCode,
+		logging.FieldHttpResponseStatusReason: resp.Status,
+		logging.FieldHttpResponseBody:         redactBody(body),
+	}
+	addHeaderFields(fields, resp.Header)
+	tflog.Debug(ctx, "Received HTTP Response", fields)
+
+	return resp, nil
+}
+
+func addHeaderFields(fields map[string]interface{}, header http.Header) {
+	for key, values := range redactHeader(header) {
+		if len(values) == 1 {
+			fields[key] = values[0]
+		} else {
+			fields[key] = values
+		}
+	}
+}
+
+// redactHeader returns a copy of the header with the values of the sensitive headers redacted.
+// The authentication scheme of the Authorization header is kept, e.g. "Bearer ***".
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, key := range sensitiveHeaders {
+		values := redacted[http.CanonicalHeaderKey(key)]
+		for i, value := range values {
+			if scheme, _, ok := strings.Cut(value, " "); ok && strings.HasSuffix(key, "Authorization") {
+				values[i] = scheme + " " + RedactedValue
+			} else {
+				values[i] = RedactedValue
+			}
+		}
+	}
+	return redacted
+}
+
+// redactBody returns the body with the values of the sensitive JSON keys redacted.
+// A body that isn't JSON is returned as is.
+func redactBody(body []byte) string {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if isSensitiveKey(key) {
+				value[key] = RedactedValue
+				continue
+			}
+			value[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	return sensitiveKeys[key] || strings.Contains(key, "password") || strings.Contains(key, "secret")
+}
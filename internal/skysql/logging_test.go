@@ -0,0 +1,84 @@
+package skysql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactingTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		if req.Method == http.MethodPost {
+			json.NewEncoder(w).Encode(&organization.Project{Id: "project-id", Name: "production"})
+			return
+		}
+		json.NewEncoder(w).Encode(&provisioning.Credentials{Username: "dbpgf00000001", Password: "secret-password", Host: "dbpgf00000001.sysp0000.db.skysql.net"})
+	}))
+	defer ts.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := New(ts.URL, "secret-token", WithRetryPolicy(0, 0))
+
+	credentials, err := client.GetServiceCredentialsByID(ctx, "dbpgf00000001")
+	require.NoError(t, err)
+	require.Equal(t, "secret-password", credentials.Password, "the response must not be modified")
+
+	project, err := client.CreateProject(ctx, &organization.CreateProjectRequest{Name: "production"})
+	require.NoError(t, err)
+	require.Equal(t, "project-id", project.Id)
+
+	require.NotContains(t, output.String(), "secret-")
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	require.Equal(t, "Sending HTTP Request", entries[0]["@message"])
+	require.Equal(t, "Bearer ***", entries[0]["Authorization"])
+	require.Equal(t, "/provisioning/v1/services/dbpgf00000001/security/credentials", entries[0][logging.FieldHttpRequestUri])
+
+	require.Equal(t, "Received HTTP Response", entries[1]["@message"])
+	require.Equal(t, "***", entries[1]["Set-Cookie"])
+	require.JSONEq(t, `{"username": "dbpgf00000001", "password": "***", "host": "dbpgf00000001.sysp0000.db.skysql.net"}`,
+		entries[1][logging.FieldHttpResponseBody].(string))
+	require.Equal(t, entries[0][logging.FieldHttpTransactionId], entries[1][logging.FieldHttpTransactionId])
+
+	require.JSONEq(t, `{"name": "production", "description": "", "is_default": false}`, entries[2][logging.FieldHttpRequestBody].(string), "the request body must be logged")
+}
+
+func TestRedactBody(t *testing.T) {
+	for name, tc := range map[string]struct {
+		body     string
+		expected string
+	}{
+		"nested keys": {
+			body:     `{"services": [{"name": "db", "root_password": "secret", "credentials": {"Password": "secret", "client_secret": "secret"}}]}`,
+			expected: `{"services": [{"name": "db", "root_password": "***", "credentials": {"Password": "***", "client_secret": "***"}}]}`,
+		},
+		"tokens": {
+			body:     `{"access_token": "secret", "token": "secret", "expires_at": "2023-03-01T12:00:00Z"}`,
+			expected: `{"access_token": "***", "token": "***", "expires_at": "2023-03-01T12:00:00Z"}`,
+		},
+		"numbers are kept": {
+			body:     `{"storage": 100, "volume_iops": 3000}`,
+			expected: `{"storage": 100, "volume_iops": 3000}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.JSONEq(t, tc.expected, redactBody([]byte(tc.body)))
+		})
+	}
+
+	require.Equal(t, "not json", redactBody([]byte("not json")))
+}
@@ -0,0 +1,218 @@
+package skysql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the number of items requested per page by the list methods.
+const DefaultPageSize = 100
+
+// DefaultMaxListItems is the maximum number of items read by a list method, see WithMaxListItems.
+const DefaultMaxListItems = 10000
+
+// maxItemsParam carries the limit of WithMaxItems to the paginator, which removes it from the query.
+const maxItemsParam = "max_items"
+
+// ErrorTooManyItems is returned by a list method when the list has more items than the client reads.
+var ErrorTooManyItems = errors.New("skysql list has too many items")
+
+// WithPageSize sets the number of items requested per page by a list method.
+// The list methods read every page, so it only changes the number of requests.
+func WithPageSize(value uint) func(url.Values) {
+	return func(values url.Values) {
+		values.Set("page_size", strconv.Itoa(int(value)))
+	}
+}
+
+// WithMaxItems stops a list method once it has read the given number of items,
+// e.g. to check that the API can be reached with a single small request.
+func WithMaxItems(value uint) func(url.Values) {
+	return func(values url.Values) {
+		values.Set(maxItemsParam, strconv.Itoa(int(value)))
+	}
+}
+
+// WithMaxListItems caps the number of items read by the list methods of the client.
+// A list with more items is an error, rather than being silently truncated.
+func WithMaxListItems(value int) Option {
+	return func(c *Client) {
+		c.maxListItems = value
+	}
+}
+
+// Pagination is how a list endpoint is paged.
+type Pagination string
+
+const (
+	// PaginationPages requests the pages by number, with the page and page_size parameters.
+	// It is the pagination of every SkySQL list endpoint the client reads.
+	PaginationPages Pagination = "pages"
+	// PaginationOffset requests the pages by the position of their first item, with the offset and limit parameters.
+	PaginationOffset Pagination = "offset"
+	// PaginationToken requests the next page with the page_token parameter set to the next_page_token
+	// of the previous page, which returns its items in an object: {"items": [...], "next_page_token": "..."}.
+	PaginationToken Pagination = "token"
+)
+
+// paginationParam carries the pagination of WithPagination to the paginator, which removes it from the query.
+const paginationParam = "pagination"
+
+// WithPagination sets how a list method pages the endpoint, PaginationPages by default.
+func WithPagination(pagination Pagination) func(url.Values) {
+	return func(values url.Values) {
+		values.Set(paginationParam, string(pagination))
+	}
+}
+
+// listAll reads every page of the list at the path, with the query parameters set by the options.
+//
+// With PaginationPages, the pages are numbered from 1 and the first one is requested without a page
+// parameter. With PaginationOffset, the pages are requested from offset 0, and page_size is sent as limit.
+// Either way the last page is the one with fewer items than the page size. An empty page also ends the
+// list, and so does a page that starts with the same item as the previous one: the endpoint ignores the
+// page parameter and returns the first page again. An endpoint that ignores the paging parameters returns
+// the whole list in the first page.
+//
+// With PaginationToken, the pages are read until one has no next_page_token. An endpoint that returns
+// a plain list has a single page.
+func listAll[T any](ctx context.Context, c *Client, path string, options ...func(url.Values)) ([]T, error) {
+	query := url.Values{}
+	query.Set("page_size", strconv.Itoa(DefaultPageSize))
+	for _, option := range options {
+		option(query)
+	}
+
+	pagination := PaginationPages
+	if value := query.Get(paginationParam); value != "" {
+		pagination = Pagination(value)
+		query.Del(paginationParam)
+	}
+	maxItems, limited := c.maxListItems, false
+	if value := query.Get(maxItemsParam); value != "" {
+		maxItems, _ = strconv.Atoi(value)
+		limited = true
+		query.Del(maxItemsParam)
+	}
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+	if limited && (pageSize <= 0 || pageSize > maxItems) {
+		pageSize = maxItems
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if pagination == PaginationOffset {
+		query.Set("limit", query.Get("page_size"))
+		query.Del("page_size")
+	}
+
+	items := make([]T, 0)
+	previousID, pageToken := "", ""
+	for page := 1; ; page++ {
+		switch pagination {
+		case PaginationOffset:
+			query.Set("offset", strconv.Itoa(len(items)))
+		case PaginationToken:
+			if pageToken != "" {
+				query.Set("page_token", pageToken)
+			}
+		default:
+			if page > 1 {
+				query.Set("page", strconv.Itoa(page))
+			}
+		}
+		result, nextPageToken, err := getPage[T](ctx, c, path, query)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(result) == 0 {
+			return items, nil
+		}
+		firstID := itemID(result[0])
+		if page > 1 && firstID == previousID {
+			return items, nil
+		}
+		previousID = firstID
+		items = append(items, result...)
+		if limited && len(items) >= maxItems {
+			return items[:maxItems], nil
+		}
+		if maxItems > 0 && len(items) > maxItems {
+			return nil, fmt.Errorf("%w: %s has more than %d items", ErrorTooManyItems, path, maxItems)
+		}
+		if pagination == PaginationToken {
+			if nextPageToken == "" {
+				return items, nil
+			}
+			pageToken = nextPageToken
+			continue
+		}
+		if pageSize <= 0 || len(result) != pageSize {
+			return items, nil
+		}
+	}
+}
+
+// getPage reads a page of the list at the path. The page is either a plain list, or an object
+// with the items and the token of the next page.
+func getPage[T any](ctx context.Context, c *Client, path string, query url.Values) ([]T, string, error) {
+	resp, err := c.HTTPClient.R().
+		SetHeader("Accept", "application/json").
+		SetQueryParamsFromValues(query).
+		SetError(&ErrorResponse{}).
+		SetContext(ctx).
+		Get(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.IsError() {
+		return nil, "", handleError(resp)
+	}
+
+	body := bytes.TrimSpace(resp.Body())
+	if len(body) == 0 {
+		return nil, "", nil
+	}
+	if body[0] != '{' {
+		var items []T
+		err = json.Unmarshal(body, &items)
+		return items, "", err
+	}
+	var page struct {
+		Items         []T    `json:"items"`
+		NextPageToken string `json:"next_page_token"`
+	}
+	err = json.Unmarshal(body, &page)
+	return page.Items, page.NextPageToken, err
+}
+
+// itemID returns the id of a list item, or the whole item when it has no id.
+func itemID(item any) string {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return ""
+	}
+	var value struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &value); err == nil && value.ID != "" {
+		return value.ID
+	}
+	return string(data)
+}
+
+// cachedListAll is listAll with the catalog cache of the client. The whole list is cached,
+// keyed by the path and the query parameters set by the options.
+func cachedListAll[T any](ctx context.Context, c *Client, path string, options ...func(url.Values)) ([]T, error) {
+	query := url.Values{}
+	for _, option := range options {
+		option(query)
+	}
+	return cachedList(c.catalog, path+"?"+query.Encode(), func() ([]T, error) {
+		return listAll[T](ctx, c, path, options...)
+	})
+}
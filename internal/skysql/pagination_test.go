@@ -0,0 +1,186 @@
+package skysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/stretchr/testify/require"
+)
+
+// pagingMode is how the projects server pages the list.
+type pagingMode int
+
+const (
+	// pagingFull honours page and page_size.
+	pagingFull pagingMode = iota
+	// pagingIgnored returns the whole list in every page.
+	pagingIgnored
+	// pagingSizeOnly honours page_size but always returns the first page.
+	pagingSizeOnly
+	// pagingOffset honours offset and limit.
+	pagingOffset
+	// pagingToken honours page_token and page_size, and returns the items with the next page token.
+	pagingToken
+)
+
+// newProjectsServer serves the given number of projects, paginated according to the mode.
+func newProjectsServer(t *testing.T, count int, mode pagingMode) (*httptest.Server, *[]url.Values) {
+	projects := make([]organization.Project, count)
+	for i := range projects {
+		projects[i] = organization.Project{Id: fmt.Sprintf("project-%03d", i)}
+	}
+	queries := make([]url.Values, 0)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		queries = append(queries, query)
+		page, err := strconv.Atoi(query.Get("page"))
+		if err != nil {
+			page = 1
+		}
+		size, _ := strconv.Atoi(query.Get("page_size"))
+		if mode == pagingSizeOnly {
+			page = 1
+		}
+		start := (page - 1) * size
+		switch mode {
+		case pagingOffset:
+			start, _ = strconv.Atoi(query.Get("offset"))
+			size, _ = strconv.Atoi(query.Get("limit"))
+		case pagingToken:
+			start, _ = strconv.Atoi(query.Get("page_token"))
+		}
+		result := projects
+		if mode != pagingIgnored {
+			end := start + size
+			if start > len(projects) {
+				start = len(projects)
+			}
+			if end > len(projects) {
+				end = len(projects)
+			}
+			result = projects[start:end]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if mode != pagingToken {
+			json.NewEncoder(w).Encode(result)
+			return
+		}
+		nextPageToken := ""
+		if start+len(result) < len(projects) {
+			nextPageToken = strconv.Itoa(start + len(result))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": result, "next_page_token": nextPageToken})
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &queries
+}
+
+func TestListAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("every page is read", func(t *testing.T) {
+		ts, queries := newProjectsServer(t, 2*DefaultPageSize+5, pagingFull)
+		projects, err := New(ts.URL, "token").GetProjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, projects, 2*DefaultPageSize+5)
+		require.Equal(t, "project-204", projects[204].Id)
+		require.Len(t, *queries, 3)
+		require.Empty(t, (*queries)[0].Get("page"))
+		require.Equal(t, "3", (*queries)[2].Get("page"))
+		require.Equal(t, strconv.Itoa(DefaultPageSize), (*queries)[2].Get("page_size"))
+	})
+
+	t.Run("the last page is full", func(t *testing.T) {
+		ts, queries := newProjectsServer(t, 20, pagingFull)
+		projects, err := New(ts.URL, "token").GetProjects(ctx, WithPageSize(10))
+		require.NoError(t, err)
+		require.Len(t, projects, 20)
+		require.Len(t, *queries, 3, "the empty page ends the list")
+	})
+
+	t.Run("the endpoint ignores paging", func(t *testing.T) {
+		ts, queries := newProjectsServer(t, 15, pagingIgnored)
+		projects, err := New(ts.URL, "token").GetProjects(ctx, WithPageSize(10))
+		require.NoError(t, err)
+		require.Len(t, projects, 15)
+		require.Len(t, *queries, 1)
+	})
+
+	t.Run("the list has exactly one page of items", func(t *testing.T) {
+		ts, queries := newProjectsServer(t, DefaultPageSize, pagingFull)
+		projects, err := New(ts.URL, "token").GetProjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, projects, DefaultPageSize)
+		require.Len(t, *queries, 2, "the empty page ends the list")
+	})
+
+	t.Run("the endpoint ignores the page", func(t *testing.T) {
+		ts, queries := newProjectsServer(t, DefaultPageSize, pagingSizeOnly)
+		projects, err := New(ts.URL, "token").GetProjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, projects, DefaultPageSize)
+		require.Equal(t, "project-099", projects[DefaultPageSize-1].Id)
+		require.Len(t, *queries, 2, "the repeated page ends the list")
+
+		ts, queries = newProjectsServer(t, 25, pagingSizeOnly)
+		projects, err = New(ts.URL, "token").GetProjects(ctx, WithPageSize(10))
+		require.NoError(t, err)
+		require.Len(t, projects, 10, "only the first page can be read")
+		require.Len(t, *queries, 2)
+	})
+
+	t.Run("offset pagination", func(t *testing.T) {
+		ts, queries := newProjectsServer(t, 25, pagingOffset)
+		projects, err := New(ts.URL, "token").GetProjects(ctx, WithPageSize(10), WithPagination(PaginationOffset))
+		require.NoError(t, err)
+		require.Len(t, projects, 25)
+		require.Equal(t, "project-024", projects[24].Id)
+		require.Len(t, *queries, 3)
+		require.Equal(t, "limit=10&offset=0", (*queries)[0].Encode())
+		require.Equal(t, "limit=10&offset=20", (*queries)[2].Encode())
+	})
+
+	t.Run("token pagination", func(t *testing.T) {
+		ts, queries := newProjectsServer(t, 25, pagingToken)
+		projects, err := New(ts.URL, "token").GetProjects(ctx, WithPageSize(10), WithPagination(PaginationToken))
+		require.NoError(t, err)
+		require.Len(t, projects, 25)
+		require.Equal(t, "project-024", projects[24].Id)
+		require.Len(t, *queries, 3, "the page without a token ends the list")
+		require.Equal(t, "page_size=10", (*queries)[0].Encode())
+		require.Equal(t, "page_size=10&page_token=20", (*queries)[2].Encode())
+
+		ts, queries = newProjectsServer(t, 15, pagingFull)
+		projects, err = New(ts.URL, "token").GetProjects(ctx, WithPageSize(10), WithPagination(PaginationToken))
+		require.NoError(t, err)
+		require.Len(t, projects, 10, "a plain list has a single page")
+		require.Len(t, *queries, 1)
+	})
+
+	t.Run("max items", func(t *testing.T) {
+		ts, queries := newProjectsServer(t, 20, pagingFull)
+		projects, err := New(ts.URL, "token").GetProjects(ctx, WithMaxItems(1))
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		require.Len(t, *queries, 1)
+		require.Equal(t, "page_size=1", (*queries)[0].Encode())
+		require.Empty(t, (*queries)[0].Get(maxItemsParam), "the limit is not sent to the API")
+	})
+
+	t.Run("max list items", func(t *testing.T) {
+		ts, _ := newProjectsServer(t, 25, pagingFull)
+		_, err := New(ts.URL, "token", WithMaxListItems(20)).GetProjects(ctx, WithPageSize(10))
+		require.ErrorIs(t, err, ErrorTooManyItems)
+
+		projects, err := New(ts.URL, "token", WithMaxListItems(25)).GetProjects(ctx, WithPageSize(10))
+		require.NoError(t, err)
+		require.Len(t, projects, 25)
+	})
+}
@@ -0,0 +1,8 @@
+package provisioning
+
+type Region struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Provider    string `json:"provider"`
+}
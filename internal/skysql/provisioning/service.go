@@ -3,6 +3,7 @@ package provisioning
 type Service struct {
 	ID            string     `json:"id"`
 	Name          string     `json:"name"`
+	ProjectID     string     `json:"project_id,omitempty"`
 	Region        string     `json:"region"`
 	Provider      string     `json:"provider"`
 	Tier          string     `json:"tier"`
@@ -0,0 +1,20 @@
+package provisioning
+
+// Size is an instance size of the catalog, e.g. sky-2x8.
+type Size struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	DisplayName  string `json:"display_name"`
+	ServiceType  string `json:"service_type"`
+	Provider     string `json:"provider"`
+	Tier         string `json:"tier"`
+	Architecture string `json:"architecture"`
+	// CPU is the number of virtual CPUs, e.g. "2 vCPU"
+	CPU string `json:"cpu"`
+	// RAM is the amount of memory, e.g. "8 GB"
+	RAM     string `json:"ram"`
+	Type    string `json:"type"`
+	Default bool   `json:"default"`
+	// Topologies are the topologies the size is available for, empty when the API doesn't report them
+	Topologies []string `json:"topologies,omitempty"`
+}
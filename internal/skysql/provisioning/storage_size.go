@@ -0,0 +1,9 @@
+package provisioning
+
+type StorageSize struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+	Tier     string `json:"tier"`
+	// Size is the storage size in GB
+	Size int64 `json:"size"`
+}
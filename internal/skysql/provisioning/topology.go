@@ -0,0 +1,11 @@
+package provisioning
+
+type Topology struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	DisplayName         string `json:"display_name"`
+	ServiceType         string `json:"service_type"`
+	SupportsMaxscale    bool   `json:"supports_maxscale"`
+	SupportsReplication bool   `json:"supports_replication"`
+	SupportsNoSQL       bool   `json:"supports_nosql"`
+}
@@ -0,0 +1,5 @@
+package provisioning
+
+type UpdateServiceVersionRequest struct {
+	Version string `json:"version"`
+}
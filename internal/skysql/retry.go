@@ -0,0 +1,137 @@
+package skysql
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const DefaultRetryCount = 3
+const DefaultRetryWaitTime = 5 * time.Second
+const DefaultRetryMaxWaitTime = 20 * time.Second
+
+// IdempotencyKeyHeader marks a non-idempotent request as safe to retry.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+var retryableStatusCodes = []int{
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// WithRetryPolicy sets the maximum number of retries and the maximum wait time between two attempts.
+// Retries use exponential backoff with jitter, unless the API asks to wait with the Retry-After header.
+func WithRetryPolicy(maxRetries int, maxWaitTime time.Duration) Option {
+	return func(c *Client) {
+		c.HTTPClient.SetRetryCount(maxRetries)
+		if maxWaitTime > 0 {
+			if maxWaitTime < c.HTTPClient.RetryWaitTime {
+				c.HTTPClient.SetRetryWaitTime(maxWaitTime)
+			}
+			c.HTTPClient.SetRetryMaxWaitTime(maxWaitTime)
+		}
+	}
+}
+
+// shouldRetry is a resty retry condition. Rate limited requests are always retried,
+// because the API rejected them before doing any work. Gateway errors, server errors and
+// transient network errors are only retried for idempotent requests, or for requests
+// made safe with an idempotency key, so a retry can not create a resource twice.
+func shouldRetry(resp *resty.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		if !isTransientError(err) {
+			return false
+		}
+		return resp == nil || resp.Request == nil || isSafeToRetry(resp.Request)
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !isSafeToRetry(resp.Request) {
+		return false
+	}
+
+	if resp.StatusCode() == http.StatusInternalServerError {
+		return true
+	}
+
+	for _, code := range retryableStatusCodes {
+		if resp.StatusCode() == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSafeToRetry(req *resty.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+func isTransientError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsTemporary {
+		return true
+	}
+	return false
+}
+
+// retryAfter honors the Retry-After header of 429 and 503 responses.
+// Returning zero lets resty fall back to exponential backoff with jitter.
+func retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if resp == nil {
+		return 0, nil
+	}
+	if resp.StatusCode() != http.StatusTooManyRequests && resp.StatusCode() != http.StatusServiceUnavailable {
+		return 0, nil
+	}
+	return parseRetryAfter(resp.Header().Get("Retry-After"), time.Now()), nil
+}
+
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
@@ -0,0 +1,123 @@
+package skysql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		idempotent    bool
+		statusCodes   []int
+		retryAfter    string
+		expectCalls   int
+		expectSuccess bool
+	}{
+		{
+			name:          "get is retried on gateway errors",
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			expectCalls:   3,
+			expectSuccess: true,
+		},
+		{
+			name:          "get is retried on internal server error",
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusInternalServerError, http.StatusOK},
+			expectCalls:   2,
+			expectSuccess: true,
+		},
+		{
+			name:        "get gives up after max retries",
+			method:      http.MethodGet,
+			statusCodes: []int{http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusGatewayTimeout},
+			expectCalls: 3,
+		},
+		{
+			name:        "post is not retried on gateway errors",
+			method:      http.MethodPost,
+			statusCodes: []int{http.StatusBadGateway, http.StatusOK},
+			expectCalls: 1,
+		},
+		{
+			name:          "post with idempotency key is retried on gateway errors",
+			method:        http.MethodPost,
+			idempotent:    true,
+			statusCodes:   []int{http.StatusBadGateway, http.StatusOK},
+			expectCalls:   2,
+			expectSuccess: true,
+		},
+		{
+			name:          "post is retried when rate limited",
+			method:        http.MethodPost,
+			statusCodes:   []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:    "0",
+			expectCalls:   2,
+			expectSuccess: true,
+		},
+		{
+			name:        "bad request is not retried",
+			method:      http.MethodGet,
+			statusCodes: []int{http.StatusBadRequest, http.StatusOK},
+			expectCalls: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := require.New(t)
+			calls := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				r.Equal(test.method, req.Method)
+				statusCode := test.statusCodes[calls]
+				calls++
+				if test.retryAfter != "" {
+					w.Header().Set("Retry-After", test.retryAfter)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(statusCode)
+				if statusCode == http.StatusOK {
+					r.NoError(json.NewEncoder(w).Encode(&provisioning.Service{ID: "dbdgf42002418"}))
+					return
+				}
+				r.NoError(json.NewEncoder(w).Encode(&ErrorResponse{Code: statusCode}))
+			}))
+			defer ts.Close()
+
+			client := New(ts.URL, "[token]", WithRetryPolicy(2, 10*time.Millisecond))
+
+			request := client.HTTPClient.R().
+				SetContext(context.Background()).
+				SetResult(provisioning.Service{}).
+				SetError(&ErrorResponse{})
+			if test.idempotent {
+				request.SetHeader(IdempotencyKeyHeader, "1d2c3b4a")
+			}
+			resp, err := request.Execute(test.method, "/provisioning/v1/services")
+			r.NoError(err)
+			r.Equal(test.expectCalls, calls)
+			r.Equal(test.expectSuccess, !resp.IsError())
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	r := require.New(t)
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	r.Equal(time.Duration(0), parseRetryAfter("", now))
+	r.Equal(7*time.Second, parseRetryAfter("7", now))
+	r.Equal(time.Duration(0), parseRetryAfter("-1", now))
+	r.Equal(30*time.Second, parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now))
+	r.Equal(time.Duration(0), parseRetryAfter(now.Add(-30*time.Second).Format(http.TimeFormat), now))
+	r.Equal(time.Duration(0), parseRetryAfter("soon", now))
+}
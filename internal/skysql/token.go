@@ -0,0 +1,169 @@
+package skysql
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// TokenExpiryMargin is how long before its expiry a token is refreshed, so it doesn't expire in flight.
+const TokenExpiryMargin = time.Minute
+
+// Token is a bearer token of the SkySQL API. A zero ExpiresAt means the expiry is unknown,
+// and the token is used until the API rejects it.
+type Token struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// TokenSource returns a new token, e.g. by running an external credential process.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource.
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// WithTokenSource authenticates the requests with the tokens of the source instead of a static access token.
+// The token is refreshed before it expires, and once after the API rejects it with 401 Unauthorized.
+// The request rejected with 401 is sent again with the new token right away, outside the retry policy
+// of the client, so it is refreshed even when retries are disabled.
+func WithTokenSource(source TokenSource) Option {
+	return func(c *Client) {
+		c.tokens = &tokenRefresher{source: source, now: time.Now}
+		c.HTTPClient.
+			SetAuthToken("").
+			OnBeforeRequest(c.tokens.authenticate).
+			SetTransport(c.tokens.transport(c.HTTPClient.GetClient().Transport))
+	}
+}
+
+// tokenRefresher caches the token of a source and refreshes it when it expires or is rejected.
+type tokenRefresher struct {
+	source TokenSource
+	now    func() time.Time
+
+	mu    sync.Mutex
+	token *Token
+	// rejected is set when the token was fetched because the API rejected the previous one,
+	// until the API accepts it. If the API rejects this token as well, it is not refreshed again,
+	// so a source that returns bad tokens fails fast.
+	rejected bool
+}
+
+// authenticate is a resty request middleware that sets a valid token on the request.
+func (t *tokenRefresher) authenticate(_ *resty.Client, req *resty.Request) error {
+	token, err := t.current(req.Context())
+	if err != nil {
+		return err
+	}
+	req.SetAuthToken(token.AccessToken)
+	return nil
+}
+
+func (t *tokenRefresher) current(ctx context.Context) (*Token, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != nil && (t.token.ExpiresAt.IsZero() || t.now().Add(TokenExpiryMargin).Before(t.token.ExpiresAt)) {
+		return t.token, nil
+	}
+
+	token, err := t.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t.token = token
+	return token, nil
+}
+
+// accepted notes that the API accepted the access token.
+func (t *tokenRefresher) accepted(accessToken string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != nil && accessToken == t.token.AccessToken {
+		t.rejected = false
+	}
+}
+
+// unauthorized notes that the API rejected the access token with 401 Unauthorized,
+// and reports whether the request should be sent again with a new token.
+func (t *tokenRefresher) unauthorized(accessToken string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Another request may have refreshed the token already
+	if t.token == nil || accessToken != t.token.AccessToken {
+		return true
+	}
+	if t.rejected {
+		return false
+	}
+	t.token = nil
+	t.rejected = true
+	return true
+}
+
+// transport wraps the transport with the tokenTransport of the refresher.
+func (t *tokenRefresher) transport(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &tokenTransport{tokens: t, transport: transport}
+}
+
+// tokenTransport sends a request rejected with 401 Unauthorized again with a new token.
+// The API rejects the request before doing any work, so it is safe to repeat.
+type tokenTransport struct {
+	tokens    *tokenRefresher
+	transport http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	accessToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.tokens.accepted(accessToken)
+		return resp, nil
+	}
+	// The body can't be sent again
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	if !t.tokens.unauthorized(accessToken) {
+		return resp, nil
+	}
+
+	token, err := t.tokens.current(req.Context())
+	if err != nil {
+		return resp, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	retry.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	resp, err = t.transport.RoundTrip(retry)
+	if err == nil && resp.StatusCode != http.StatusUnauthorized {
+		t.tokens.accepted(token.AccessToken)
+	}
+	return resp, err
+}
@@ -0,0 +1,101 @@
+package skysql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenSource(t *testing.T) {
+	var validToken atomic.Value
+	validToken.Store("token-1")
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if req.Header.Get("Authorization") != "Bearer "+validToken.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(&ErrorResponse{Code: http.StatusUnauthorized})
+			return
+		}
+		json.NewEncoder(w).Encode([]organization.Project{})
+	}))
+	defer ts.Close()
+
+	now := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	var issued int32
+	source := TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		n := atomic.AddInt32(&issued, 1)
+		return &Token{AccessToken: fmt.Sprintf("token-%d", n), ExpiresAt: now.Add(10 * time.Minute)}, nil
+	})
+	client := New(ts.URL, "", WithRetryPolicy(3, time.Millisecond), WithTokenSource(source))
+	client.tokens.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	t.Run("the token is reused until it expires", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := client.GetProjects(ctx)
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(1), atomic.LoadInt32(&issued))
+		require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("the token is refreshed before it expires", func(t *testing.T) {
+		now = now.Add(9*time.Minute + time.Second)
+		validToken.Store("token-2")
+		_, err := client.GetProjects(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int32(2), atomic.LoadInt32(&issued))
+		require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("the token is refreshed after a 401", func(t *testing.T) {
+		validToken.Store("token-3")
+		_, err := client.GetProjects(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int32(3), atomic.LoadInt32(&issued))
+		require.Equal(t, int32(5), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("a rejected token is refreshed only once", func(t *testing.T) {
+		validToken.Store("revoked")
+		_, err := client.GetProjects(ctx)
+		require.ErrorIs(t, err, ErrorUnauthorized)
+		require.Equal(t, int32(4), atomic.LoadInt32(&issued))
+		require.Equal(t, int32(7), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("the token is refreshed after a 401 without retries", func(t *testing.T) {
+		noRetries := New(ts.URL, "", WithRetryPolicy(0, time.Hour), WithTokenSource(source))
+		noRetries.tokens.now = func() time.Time { return now }
+		validToken.Store("token-5")
+		_, err := noRetries.GetProjects(ctx)
+		require.NoError(t, err)
+		validToken.Store("token-6")
+		start := time.Now()
+		_, err = noRetries.GetProjects(ctx)
+		require.NoError(t, err)
+		require.Less(t, time.Since(start), time.Second, "the refresh does not wait for the retry backoff")
+		require.Equal(t, int32(6), atomic.LoadInt32(&issued))
+		require.Equal(t, int32(10), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("source errors are returned", func(t *testing.T) {
+		failing := New(ts.URL, "", WithRetryPolicy(3, time.Millisecond), WithTokenSource(TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+			return nil, errors.New("no credentials")
+		})))
+		_, err := failing.GetProjects(ctx)
+		require.EqualError(t, err, "no credentials")
+		require.Equal(t, int32(10), atomic.LoadInt32(&calls))
+	})
+}
@@ -0,0 +1,80 @@
+package skysql
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the OpenTelemetry tracer of the provider.
+const TracerName = "github.com/mariadb-corporation/terraform-provider-skysql"
+
+// The attributes of the spans of the provider.
+const (
+	ServiceIDKey = attribute.Key("skysql.service_id")
+	StatusKey    = attribute.Key("skysql.status")
+	AttemptKey   = attribute.Key("skysql.attempt")
+)
+
+var servicePathPattern = regexp.MustCompile(`/services/([^/]+)`)
+
+type attemptContextKey struct{}
+
+// withAttempt is a resty request middleware that passes the attempt number of the request to the tracing transport.
+func withAttempt(_ *resty.Client, req *resty.Request) error {
+	req.SetContext(context.WithValue(req.Context(), attemptContextKey{}, req.Attempt))
+	return nil
+}
+
+// tracingTransport traces each attempt of an API request with a span, a child of the span of the request context.
+// The spans are dropped unless tracing is set up, see provider.SetupTracing.
+type tracingTransport struct {
+	transport http.RoundTripper
+}
+
+func newTracingTransport(transport http.RoundTripper) *tracingTransport {
+	return &tracingTransport{transport: transport}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attributes := []attribute.KeyValue{
+		semconv.HTTPMethod(req.Method),
+		semconv.HTTPURL(req.URL.Redacted()),
+	}
+	if attempt, ok := req.Context().Value(attemptContextKey{}).(int); ok {
+		attributes = append(attributes, AttemptKey.Int(attempt))
+	}
+	if match := servicePathPattern.FindStringSubmatch(req.URL.Path); match != nil {
+		attributes = append(attributes, ServiceIDKey.String(match[1]))
+	}
+
+	ctx, span := otel.Tracer(TracerName).Start(req.Context(), "SkySQL API "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...))
+	defer span.End()
+
+	resp, err := t.transport.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+// newTransport wraps the transport with the tracing and the redacting logging transports.
+func newTransport(transport http.RoundTripper) http.RoundTripper {
+	return newTracingTransport(newRedactingTransport(transport))
+}
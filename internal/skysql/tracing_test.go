@@ -0,0 +1,66 @@
+package skysql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingTransport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&provisioning.Service{ID: "dbpgf00000001", Status: "ready"})
+	}))
+	defer ts.Close()
+
+	ctx, parent := otel.Tracer(TracerName).Start(context.Background(), "skysql_service.Read")
+	client := New(ts.URL, "[token]", WithRetryPolicy(1, time.Millisecond))
+	_, err := client.GetServiceByID(ctx, "dbpgf00000001")
+	require.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	for i, span := range spans[:2] {
+		require.Equal(t, "SkySQL API GET", span.Name())
+		require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID(), "the attempts must be children of the operation")
+		require.Equal(t, int64(i+1), spanAttribute(span, AttemptKey).AsInt64())
+		require.Equal(t, "dbpgf00000001", spanAttribute(span, ServiceIDKey).AsString())
+	}
+
+	require.Equal(t, int64(http.StatusBadGateway), spanAttribute(spans[0], "http.status_code").AsInt64())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, int64(http.StatusOK), spanAttribute(spans[1], "http.status_code").AsInt64())
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
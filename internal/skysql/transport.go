@@ -0,0 +1,104 @@
+package skysql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// DefaultConnectTimeout is how long the client waits for a connection to the API by default.
+const DefaultConnectTimeout = 30 * time.Second
+
+// TransportConfig configures how the client connects to the API, e.g. through a corporate proxy.
+type TransportConfig struct {
+	// ProxyURL is the URL of the proxy. When it is empty, the HTTPS_PROXY and NO_PROXY environment variables are used.
+	ProxyURL string
+	// CABundleFile is a PEM file of certificate authorities trusted in addition to the system ones.
+	CABundleFile string
+	// ClientCertFile and ClientKeyFile are the PEM files of the client certificate for mutual TLS.
+	ClientCertFile string
+	ClientKeyFile  string
+	// InsecureSkipVerify disables the verification of the API certificate. It is only meant for tests.
+	InsecureSkipVerify bool
+	// ConnectTimeout is how long to wait for a connection. Zero means DefaultConnectTimeout.
+	ConnectTimeout time.Duration
+}
+
+// NewTransport returns an HTTP transport with the settings of the config,
+// based on the settings of http.DefaultTransport.
+func NewTransport(config TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("the proxy URL %q is not valid, use a URL like http://proxy.example.com:3128", config.ProxyURL)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("the proxy URL %q has an unsupported scheme, use http, https or socks5", config.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CABundleFile != "" {
+		pem, err := os.ReadFile(config.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificate was found in the CA bundle %s", config.CABundleFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertFile != "" || config.ClientKeyFile != "" {
+		if config.ClientCertFile == "" || config.ClientKeyFile == "" {
+			return nil, fmt.Errorf("both the client certificate and the client key are required for mutual TLS")
+		}
+		certificate, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	connectTimeout := config.ConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = DefaultConnectTimeout
+	}
+	transport.DialContext = (&net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+
+	return transport, nil
+}
+
+// WithTransport sends the requests with the transport, e.g. one created by NewTransport.
+// The transport is wrapped by the tracing and the redacting logging transports, like the default one.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		transport = newTransport(transport)
+		if c.tokens != nil {
+			transport = c.tokens.transport(transport)
+		}
+		c.HTTPClient.SetTransport(transport)
+	}
+}
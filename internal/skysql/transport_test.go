@@ -0,0 +1,120 @@
+package skysql
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]organization.Project{{Id: req.Host}})
+	})
+	newClient := func(t *testing.T, baseURL string, config TransportConfig) *Client {
+		transport, err := NewTransport(config)
+		require.NoError(t, err)
+		return New(baseURL, "[token]", WithRetryPolicy(0, 0), WithTransport(transport))
+	}
+
+	t.Run("proxy", func(t *testing.T) {
+		proxy := httptest.NewServer(handler)
+		defer proxy.Close()
+
+		projects, err := newClient(t, "http://api.skysql.invalid", TransportConfig{ProxyURL: proxy.URL}).GetProjects(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "api.skysql.invalid", projects[0].Id, "the request must be sent through the proxy")
+	})
+
+	t.Run("CA bundle", func(t *testing.T) {
+		ts := httptest.NewTLSServer(handler)
+		defer ts.Close()
+
+		_, err := newClient(t, ts.URL, TransportConfig{}).GetProjects(ctx)
+		require.ErrorContains(t, err, "certificate")
+
+		caBundle := writePEM(t, dir, "ca.pem", "CERTIFICATE", ts.Certificate().Raw)
+		_, err = newClient(t, ts.URL, TransportConfig{CABundleFile: caBundle}).GetProjects(ctx)
+		require.NoError(t, err)
+
+		_, err = newClient(t, ts.URL, TransportConfig{InsecureSkipVerify: true}).GetProjects(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("mutual TLS", func(t *testing.T) {
+		certFile, keyFile, certificate := generateClientCertificate(t, dir)
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(certificate)
+		ts := httptest.NewUnstartedServer(handler)
+		ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+		ts.StartTLS()
+		defer ts.Close()
+
+		_, err := newClient(t, ts.URL, TransportConfig{InsecureSkipVerify: true}).GetProjects(ctx)
+		require.Error(t, err)
+
+		_, err = newClient(t, ts.URL, TransportConfig{InsecureSkipVerify: true, ClientCertFile: certFile, ClientKeyFile: keyFile}).GetProjects(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		notPEM := filepath.Join(dir, "not.pem")
+		require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+		for config, expected := range map[TransportConfig]string{
+			{ProxyURL: "proxy.example.com:3128"}:            `the proxy URL "proxy.example.com:3128" is not valid`,
+			{ProxyURL: "ftp://proxy.example.com"}:           "unsupported scheme",
+			{CABundleFile: filepath.Join(dir, "none")}:      "unable to read the CA bundle",
+			{CABundleFile: notPEM}:                          "no PEM certificate was found in the CA bundle",
+			{ClientCertFile: notPEM}:                        "both the client certificate and the client key are required",
+			{ClientCertFile: notPEM, ClientKeyFile: notPEM}: "unable to load the client certificate",
+		} {
+			_, err := NewTransport(config)
+			require.ErrorContains(t, err, expected)
+		}
+	})
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func generateClientCertificate(t *testing.T, dir string) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return writePEM(t, dir, "client.pem", "CERTIFICATE", der), writePEM(t, dir, "client-key.pem", "EC PRIVATE KEY", keyDER), certificate
+}
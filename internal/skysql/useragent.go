@@ -0,0 +1,35 @@
+package skysql
+
+import (
+	"runtime"
+	"strings"
+)
+
+// UserAgentProduct is the product of the User-Agent header of the API requests.
+const UserAgentProduct = "terraform-provider-skysql"
+
+// UserAgent returns the User-Agent header of the API requests, e.g.
+// "terraform-provider-skysql/1.2.0 terraform/1.4.6 go/1.20.3 deploy-pipeline/42".
+// The unknown versions are left out, and the suffix is appended as is.
+func UserAgent(providerVersion, terraformVersion, suffix string) string {
+	product := UserAgentProduct
+	if providerVersion != "" {
+		product += "/" + providerVersion
+	}
+	parts := []string{product}
+	if terraformVersion != "" {
+		parts = append(parts, "terraform/"+terraformVersion)
+	}
+	parts = append(parts, "go/"+strings.TrimPrefix(runtime.Version(), "go"))
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		parts = append(parts, suffix)
+	}
+	return strings.Join(parts, " ")
+}
+
+// WithUserAgent sets the User-Agent header of the API requests, see UserAgent.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.HTTPClient.SetHeader("User-Agent", userAgent)
+	}
+}
@@ -0,0 +1,60 @@
+package skysql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAgent(t *testing.T) {
+	goVersion := "go/" + strings.TrimPrefix(runtime.Version(), "go")
+	for name, tc := range map[string]struct {
+		providerVersion  string
+		terraformVersion string
+		suffix           string
+		expected         string
+	}{
+		"all versions": {
+			providerVersion:  "1.2.0",
+			terraformVersion: "1.4.6",
+			expected:         "terraform-provider-skysql/1.2.0 terraform/1.4.6 " + goVersion,
+		},
+		"suffix": {
+			providerVersion:  "1.2.0",
+			terraformVersion: "1.4.6",
+			suffix:           " deploy-pipeline/42 ",
+			expected:         "terraform-provider-skysql/1.2.0 terraform/1.4.6 " + goVersion + " deploy-pipeline/42",
+		},
+		"unknown versions": {
+			expected: "terraform-provider-skysql " + goVersion,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, UserAgent(tc.providerVersion, tc.terraformVersion, tc.suffix))
+		})
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var userAgents []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userAgents = append(userAgents, req.UserAgent())
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]organization.Project{})
+	}))
+	defer ts.Close()
+
+	_, err := New(ts.URL, "token").GetProjects(context.Background())
+	require.NoError(t, err)
+	_, err = New(ts.URL, "token", WithUserAgent("terraform-provider-skysql/1.2.0 terraform/1.4.6")).GetProjects(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{UserAgent("", "", ""), "terraform-provider-skysql/1.2.0 terraform/1.4.6"}, userAgents)
+}
@@ -0,0 +1,100 @@
+package skysqltest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+// Fault is an injected failure of the matching requests.
+type Fault struct {
+	// Method matches the request method. Empty matches any method.
+	Method string
+	// Path matches requests whose path starts with the value. Empty matches any path.
+	Path string
+	// Times is the number of requests the fault applies to. Zero applies it to all matching requests.
+	Times int
+	// Latency delays the response.
+	Latency time.Duration
+	// StatusCode replies with the error status instead of handling the request.
+	StatusCode int
+	// RetryAfter is sent as the Retry-After header together with StatusCode.
+	RetryAfter string
+	// Drop handles the request, so the state changes, but closes the connection without a response.
+	Drop bool
+}
+
+// InjectFault adds a fault. Faults are matched in the order they were added.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all the faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// matchFault returns a copy of the first fault that matches the request and consumes one use of it.
+func (s *Server) matchFault(req *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != req.Method {
+			continue
+		}
+		if fault.Path != "" && !strings.HasPrefix(req.URL.Path, fault.Path) {
+			continue
+		}
+		matched := *fault
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return &matched
+	}
+	return nil
+}
+
+// applyFault handles the request according to the fault and reports whether the request was handled.
+func (s *Server) applyFault(fault *Fault, w http.ResponseWriter, req *http.Request) bool {
+	if fault.Latency > 0 {
+		select {
+		case <-time.After(fault.Latency):
+		case <-req.Context().Done():
+			return true
+		}
+	}
+
+	if fault.StatusCode != 0 {
+		if fault.RetryAfter != "" {
+			w.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		writeError(w, fault.StatusCode, http.StatusText(fault.StatusCode))
+		return true
+	}
+
+	if fault.Drop {
+		s.route(httptest.NewRecorder(), req)
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			panic("skysqltest: response writer does not support hijacking")
+		}
+		conn, _, err := hijacker.Hijack()
+		if err == nil {
+			conn.Close()
+		}
+		return true
+	}
+
+	return false
+}
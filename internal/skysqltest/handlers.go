@@ -0,0 +1,669 @@
+package skysqltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/autonomous"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+const servicesPath = "/provisioning/v1/services"
+
+// outboundIP is the outbound IP of every active service.
+const outboundIP = "203.0.113.10"
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: req.Method, Path: req.URL.Path, Query: req.URL.RawQuery})
+	accessToken := s.accessToken
+	s.mu.Unlock()
+
+	if accessToken != "" && req.Header.Get("Authorization") != "Bearer "+accessToken {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if fault := s.matchFault(req); fault != nil && s.applyFault(fault, w, req) {
+		return
+	}
+
+	s.route(w, req)
+}
+
+func (s *Server) route(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	switch {
+	case path == "/organization/v1/projects" && req.Method == http.MethodGet:
+		s.getProjects(w, req)
+	case path == "/organization/v1/projects" && req.Method == http.MethodPost:
+		s.createProject(w, req)
+	case strings.HasPrefix(path, "/organization/v1/projects/") && req.Method == http.MethodPut:
+		s.updateProject(w, req, strings.TrimPrefix(path, "/organization/v1/projects/"))
+	case strings.HasPrefix(path, "/organization/v1/projects/") && req.Method == http.MethodDelete:
+		s.deleteProject(w, strings.TrimPrefix(path, "/organization/v1/projects/"))
+	case path == "/provisioning/v1/versions" && req.Method == http.MethodGet:
+		s.getVersions(w, req)
+	case path == "/provisioning/v1/regions" && req.Method == http.MethodGet:
+		s.getRegions(w, req)
+	case path == "/provisioning/v1/topologies" && req.Method == http.MethodGet:
+		s.getTopologies(w, req)
+	case path == "/provisioning/v1/sizes" && req.Method == http.MethodGet:
+		s.getSizes(w, req)
+	case path == "/provisioning/v1/storage-sizes" && req.Method == http.MethodGet:
+		s.getStorageSizes(w, req)
+	case strings.HasPrefix(path, "/provisioning/v1/regions/") && strings.HasSuffix(path, "/zones") && req.Method == http.MethodGet:
+		s.getZones(w, req, strings.TrimSuffix(strings.TrimPrefix(path, "/provisioning/v1/regions/"), "/zones"))
+	case path == servicesPath && req.Method == http.MethodPost:
+		s.createService(w, req)
+	case path == servicesPath && req.Method == http.MethodGet:
+		s.listServices(w, req)
+	case strings.HasPrefix(path, servicesPath+"/"):
+		s.routeService(w, req, strings.Split(strings.TrimPrefix(path, servicesPath+"/"), "/"))
+	case path == "/als/v1/actions" && req.Method == http.MethodPost:
+		s.setActions(w, req)
+	case path == "/als/v1/actions" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, paginate(s.serviceActions(req.URL.Query().Get("service_id")), req.URL.Query()))
+	case strings.HasPrefix(path, "/als/v1/actions/") && req.Method == http.MethodDelete:
+		s.deleteAction(w, strings.TrimPrefix(path, "/als/v1/actions/"))
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) routeService(w http.ResponseWriter, req *http.Request, segments []string) {
+	svc, ok := s.services[segments[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Service not found")
+		return
+	}
+
+	resource := strings.Join(segments[1:], "/")
+	switch {
+	case resource == "" && req.Method == http.MethodGet:
+		s.getService(w, svc)
+	case resource == "" && req.Method == http.MethodDelete:
+		svc.deleting = true
+		s.transition(svc, "pending_delete", s.stateMachine.DeleteStates)
+		w.WriteHeader(http.StatusAccepted)
+	case resource == "security/credentials" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, svc.credentials)
+	case resource == "security/allowlist" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, allowListResponse(svc))
+	case resource == "security/allowlist" && req.Method == http.MethodPut:
+		var allowList []provisioning.AllowListItem
+		if !decode(w, req, &allowList) {
+			return
+		}
+		for i := range svc.Endpoints {
+			svc.Endpoints[i].AllowList = allowList
+		}
+		s.transition(svc, "pending_modifying", s.stateMachine.UpdateStates)
+		writeJSON(w, http.StatusOK, allowListResponse(svc))
+	case resource == "power" && req.Method == http.MethodPost:
+		var powerState provisioning.PowerStateRequest
+		if !decode(w, req, &powerState) {
+			return
+		}
+		svc.IsActive = powerState.IsActive
+		// A stopped service releases its outbound IPs and gets new ones when it starts again.
+		if powerState.IsActive {
+			svc.OutboundIps = []string{outboundIP}
+			s.transition(svc, "pending_start", s.stateMachine.StartStates)
+		} else {
+			svc.OutboundIps = nil
+			s.transition(svc, "pending_stop", s.stateMachine.StopStates)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case resource == "endpoints" && req.Method == http.MethodPatch:
+		s.patchEndpoints(w, req, svc)
+	case resource == "size" && req.Method == http.MethodPost:
+		var size provisioning.UpdateServiceSizeRequest
+		if !decode(w, req, &size) {
+			return
+		}
+		svc.Size = size.Size
+		s.transition(svc, "pending_scale", s.stateMachine.UpdateStates)
+		w.WriteHeader(http.StatusAccepted)
+	case resource == "nodes" && req.Method == http.MethodPost:
+		var nodes provisioning.UpdateServiceNodesNumberRequest
+		if !decode(w, req, &nodes) {
+			return
+		}
+		svc.Nodes = int(nodes.Nodes)
+		s.transition(svc, "pending_scale", s.stateMachine.UpdateStates)
+		w.WriteHeader(http.StatusAccepted)
+	case resource == "storage" && req.Method == http.MethodPatch:
+		var storage provisioning.UpdateStorageRequest
+		if !decode(w, req, &storage) {
+			return
+		}
+		if storage.Size > 0 {
+			svc.StorageVolume.Size = int(storage.Size)
+		}
+		if storage.IOPS > 0 {
+			svc.StorageVolume.IOPS = int(storage.IOPS)
+		}
+		s.transition(svc, "pending_scale", s.stateMachine.UpdateStates)
+		w.WriteHeader(http.StatusAccepted)
+	case resource == "version" && req.Method == http.MethodPost:
+		s.upgradeVersion(w, req, svc)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// upgradeVersion accepts any known version of the service topology, the same way the API
+// leaves the upgrade policy to its clients.
+func (s *Server) upgradeVersion(w http.ResponseWriter, req *http.Request, svc *service) {
+	var upgrade provisioning.UpdateServiceVersionRequest
+	if !decode(w, req, &upgrade) {
+		return
+	}
+	for _, version := range s.versions {
+		if version.Topology == svc.Topology && version.Name == upgrade.Version {
+			svc.Version = upgrade.Version
+			s.transition(svc, "pending_upgrade", s.stateMachine.UpdateStates)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+	}
+	writeError(w, http.StatusBadRequest, fmt.Sprintf("Version %q is not available for the %q topology", upgrade.Version, svc.Topology))
+}
+
+// getService moves the service to its next status before returning it.
+func (s *Server) getService(w http.ResponseWriter, svc *service) {
+	if len(svc.pendingStates) > 0 {
+		svc.Status = svc.pendingStates[0]
+		svc.pendingStates = svc.pendingStates[1:]
+	} else if svc.deleting {
+		delete(s.services, svc.ID)
+		for id, action := range s.actions {
+			if action.ServiceID == svc.ID {
+				delete(s.actions, id)
+			}
+		}
+		writeError(w, http.StatusNotFound, "Service not found")
+		return
+	}
+	if svc.Status == "ready" && svc.FQDN == "" {
+		svc.FQDN = svc.ID + ".mdb0000001.db.skysql.net"
+		svc.credentials.Host = svc.FQDN
+	}
+	writeJSON(w, http.StatusOK, svc.Service)
+}
+
+func (s *Server) transition(svc *service, status string, states []string) {
+	svc.Status = status
+	svc.UpdatedOn = int(time.Now().Unix())
+	svc.pendingStates = append([]string(nil), states...)
+	// A stopped service settles back to stopped once it is updated.
+	if n := len(svc.pendingStates); n > 0 && !svc.IsActive && svc.pendingStates[n-1] == "ready" {
+		svc.pendingStates[n-1] = "stopped"
+	}
+}
+
+func (s *Server) createService(w http.ResponseWriter, req *http.Request) {
+	var request provisioning.CreateServiceRequest
+	if !decode(w, req, &request) {
+		return
+	}
+
+	if request.Name == "" || request.Provider == "" || request.Region == "" || request.Topology == "" {
+		writeError(w, http.StatusBadRequest, "name, provider, region and topology are required")
+		return
+	}
+	for _, svc := range s.services {
+		if svc.Name == request.Name && !svc.deleting {
+			writeError(w, http.StatusConflict, "Service with name "+request.Name+" already exists")
+			return
+		}
+	}
+
+	projectID := request.ProjectID
+	if projectID == "" {
+		for _, project := range s.projects {
+			if project.IsDefault {
+				projectID = project.Id
+			}
+		}
+	}
+
+	version := request.Version
+	if version == "" {
+		for _, v := range s.versions {
+			if v.Topology == request.Topology {
+				version = v.Name
+			}
+		}
+	}
+
+	volumeType := request.VolumeType
+	if volumeType == "" {
+		switch request.Provider {
+		case "gcp":
+			volumeType = "pd-ssd"
+		case "aws":
+			volumeType = "gp2"
+		}
+	}
+
+	mechanism := request.Mechanism
+	if mechanism == "" {
+		mechanism = "nlb"
+	}
+
+	endpoint := provisioning.Endpoint{
+		Name:            "primary",
+		Ports:           []provisioning.Port{{Name: "readwrite", Port: 3306, Purpose: "readwrite"}},
+		Mechanism:       mechanism,
+		AllowedAccounts: request.AllowedAccounts,
+		Visibility:      visibility(mechanism),
+		AllowList:       request.AllowList,
+	}
+
+	now := int(time.Now().Unix())
+	svc := &service{
+		Service: provisioning.Service{
+			ID:                 s.nextID("db"),
+			Name:               request.Name,
+			ProjectID:          projectID,
+			Region:             request.Region,
+			Provider:           request.Provider,
+			Tier:               "foundation",
+			Topology:           request.Topology,
+			Version:            version,
+			Architecture:       request.Architecture,
+			Size:               request.Size,
+			Nodes:              int(request.Nodes),
+			SSLEnabled:         request.SSLEnabled,
+			NosqlEnabled:       request.NoSQLEnabled,
+			Status:             "pending_create",
+			CreatedOn:          now,
+			UpdatedOn:          now,
+			CreatedBy:          "skysqltest",
+			UpdatedBy:          "skysqltest",
+			Endpoints:          []provisioning.Endpoint{endpoint},
+			OutboundIps:        []string{outboundIP},
+			IsActive:           true,
+			ServiceType:        request.ServiceType,
+			ReplicationEnabled: request.ReplicationEnabled,
+			PrimaryHost:        request.PrimaryHost,
+			MaxscaleNodes:      request.MaxscaleNodes,
+			MaxscaleSize:       request.MaxscaleSize,
+			AvailabilityZone:   request.AvailabilityZone,
+		},
+		pendingStates: append([]string(nil), s.stateMachine.CreateStates...),
+	}
+	svc.StorageVolume.Size = int(request.Storage)
+	svc.StorageVolume.IOPS = int(request.VolumeIOPS)
+	svc.StorageVolume.VolumeType = volumeType
+	svc.Endpoints[0].EndpointService = endpointService(svc)
+	svc.credentials = s.newCredentials(svc.ID, "")
+
+	s.services[svc.ID] = svc
+	writeJSON(w, http.StatusOK, svc.Service)
+}
+
+func (s *Server) listServices(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	services := make([]provisioning.Service, 0)
+	for _, svc := range s.services {
+		if name := query.Get("name"); name != "" && svc.Name != name {
+			continue
+		}
+		if projectID := query.Get("project_id"); projectID != "" && svc.ProjectID != projectID {
+			continue
+		}
+		services = append(services, svc.Service)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+	writeJSON(w, http.StatusOK, paginate(services, query))
+}
+
+func (s *Server) patchEndpoints(w http.ResponseWriter, req *http.Request, svc *service) {
+	var request provisioning.PatchServiceEndpointsRequest
+	if !decode(w, req, &request) {
+		return
+	}
+	if len(request) == 0 {
+		writeError(w, http.StatusBadRequest, "at least one endpoint is required")
+		return
+	}
+
+	endpoint := &svc.Endpoints[0]
+	endpoint.Mechanism = request[0].Mechanism
+	endpoint.AllowedAccounts = request[0].AllowedAccounts
+	endpoint.Visibility = request[0].Visibility
+	endpoint.EndpointService = endpointService(svc)
+	s.transition(svc, "pending_modifying", s.stateMachine.UpdateStates)
+
+	writeJSON(w, http.StatusOK, provisioning.PatchServiceEndpointsResponse{
+		{
+			Mechanism:       endpoint.Mechanism,
+			AllowedAccounts: endpoint.AllowedAccounts,
+			Visibility:      endpoint.Visibility,
+			EndpointService: endpoint.EndpointService,
+		},
+	})
+}
+
+func (s *Server) setActions(w http.ResponseWriter, req *http.Request) {
+	var request autonomous.SetAutonomousActionsRequest
+	if !decode(w, req, &request) {
+		return
+	}
+	if _, ok := s.services[request.ServiceID]; !ok {
+		writeError(w, http.StatusNotFound, "Service not found")
+		return
+	}
+
+	now := time.Now().UTC()
+	actions := make([]autonomous.ActionResponse, 0, len(request.Actions))
+	for _, requested := range request.Actions {
+		action := autonomous.ActionResponse{
+			Group:       requested.Group,
+			Enabled:     requested.Enabled,
+			Params:      requested.Params,
+			ServiceID:   request.ServiceID,
+			ServiceName: request.ServiceName,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		for id, existing := range s.actions {
+			if existing.ServiceID == request.ServiceID && existing.Group == requested.Group {
+				action.ID = id
+				action.CreatedAt = existing.CreatedAt
+			}
+		}
+		if action.ID == "" {
+			action.ID = s.nextID("action")
+		}
+		s.actions[action.ID] = action
+		actions = append(actions, action)
+	}
+
+	writeJSON(w, http.StatusOK, actions)
+}
+
+func (s *Server) deleteAction(w http.ResponseWriter, actionID string) {
+	if _, ok := s.actions[actionID]; !ok {
+		writeError(w, http.StatusNotFound, "Action not found")
+		return
+	}
+	delete(s.actions, actionID)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getProjects(w http.ResponseWriter, req *http.Request) {
+	projects := make([]organization.Project, len(s.projects))
+	copy(projects, s.projects)
+	writeJSON(w, http.StatusOK, paginate(projects, req.URL.Query()))
+}
+
+func (s *Server) createProject(w http.ResponseWriter, req *http.Request) {
+	var request organization.CreateProjectRequest
+	if !decode(w, req, &request) {
+		return
+	}
+	if request.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	for _, project := range s.projects {
+		if project.Name == request.Name {
+			writeError(w, http.StatusConflict, "Project with name "+request.Name+" already exists")
+			return
+		}
+	}
+
+	now := int(time.Now().Unix())
+	project := organization.Project{
+		Id:          s.nextID("project"),
+		Name:        request.Name,
+		Description: request.Description,
+		CreatedBy:   "skysqltest",
+		UpdatedBy:   "skysqltest",
+		CreatedOn:   now,
+		UpdatedOn:   now,
+	}
+	s.projects = append(s.projects, project)
+	s.setDefaultProject(project.Id, request.IsDefault)
+	writeJSON(w, http.StatusCreated, s.projects[len(s.projects)-1])
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, req *http.Request, projectID string) {
+	var request organization.UpdateProjectRequest
+	if !decode(w, req, &request) {
+		return
+	}
+	for i := range s.projects {
+		if s.projects[i].Id != projectID {
+			continue
+		}
+		s.projects[i].Name = request.Name
+		s.projects[i].Description = request.Description
+		s.projects[i].UpdatedOn = int(time.Now().Unix())
+		s.setDefaultProject(projectID, request.IsDefault)
+		writeJSON(w, http.StatusOK, s.projects[i])
+		return
+	}
+	writeError(w, http.StatusNotFound, "Project not found")
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, projectID string) {
+	for _, svc := range s.services {
+		if svc.ProjectID == projectID {
+			writeError(w, http.StatusConflict, "Project still has services")
+			return
+		}
+	}
+	for i := range s.projects {
+		if s.projects[i].Id == projectID {
+			s.projects = append(s.projects[:i], s.projects[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Project not found")
+}
+
+// setDefaultProject makes the project the only default project, or clears its default flag.
+func (s *Server) setDefaultProject(projectID string, isDefault bool) {
+	for i := range s.projects {
+		if s.projects[i].Id == projectID {
+			s.projects[i].IsDefault = isDefault
+		} else if isDefault {
+			s.projects[i].IsDefault = false
+		}
+	}
+}
+
+// getSizes filters the sizes by their attributes and topology.
+// A size that doesn't list its topologies is available for all of them.
+func (s *Server) getSizes(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	sizes := make([]provisioning.Size, 0)
+	for _, size := range s.sizes {
+		if topology := query.Get("topology"); topology != "" && len(size.Topologies) > 0 &&
+			!containsString(size.Topologies, topology) {
+			continue
+		}
+		if !matchQuery(query, map[string]string{
+			"provider":     size.Provider,
+			"architecture": size.Architecture,
+			"service_type": size.ServiceType,
+			"type":         size.Type,
+		}) {
+			continue
+		}
+		sizes = append(sizes, size)
+	}
+	writeJSON(w, http.StatusOK, paginate(sizes, query))
+}
+
+func (s *Server) getStorageSizes(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	storageSizes := make([]provisioning.StorageSize, 0)
+	for _, storageSize := range s.storageSizes {
+		if matchQuery(query, map[string]string{"provider": storageSize.Provider, "tier": storageSize.Tier}) {
+			storageSizes = append(storageSizes, storageSize)
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(storageSizes, query))
+}
+
+func (s *Server) getRegions(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	regions := make([]provisioning.Region, 0)
+	for _, region := range s.regions {
+		if matchQuery(query, map[string]string{"provider": region.Provider}) {
+			regions = append(regions, region)
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(regions, query))
+}
+
+func (s *Server) getTopologies(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	topologies := make([]provisioning.Topology, 0)
+	for _, topology := range s.topologies {
+		if matchQuery(query, map[string]string{"service_type": topology.ServiceType}) {
+			topologies = append(topologies, topology)
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(topologies, query))
+}
+
+// matchQuery reports whether every field that is set in the query has the given value.
+func matchQuery(query url.Values, fields map[string]string) bool {
+	for key, value := range fields {
+		if expected := query.Get(key); expected != "" && expected != value {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) getVersions(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	versions := make([]provisioning.Version, 0)
+	for _, version := range s.versions {
+		if topology := query.Get("topology"); topology != "" && version.Topology != topology {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	writeJSON(w, http.StatusOK, paginate(versions, query))
+}
+
+func (s *Server) getZones(w http.ResponseWriter, req *http.Request, region string) {
+	query := req.URL.Query()
+	zones := make([]provisioning.AvailabilityZone, 0)
+	for _, zone := range s.zones {
+		if zone.Region != region {
+			continue
+		}
+		if provider := query.Get("provider"); provider != "" && zone.Provider != provider {
+			continue
+		}
+		zones = append(zones, zone)
+	}
+	writeJSON(w, http.StatusOK, paginate(zones, query))
+}
+
+func allowListResponse(svc *service) provisioning.ReadAllowListResponse {
+	allowList := make([]provisioning.AllowListItem, 0)
+	if len(svc.Endpoints) > 0 && svc.Endpoints[0].AllowList != nil {
+		allowList = svc.Endpoints[0].AllowList
+	}
+	return provisioning.ReadAllowListResponse{{AllowList: allowList}}
+}
+
+func isPrivate(mechanism string) bool {
+	return mechanism == "privateconnect" || mechanism == "privatelink"
+}
+
+func visibility(mechanism string) string {
+	if isPrivate(mechanism) {
+		return "private"
+	}
+	return "public"
+}
+
+func endpointService(svc *service) string {
+	if !isPrivate(svc.Endpoints[0].Mechanism) {
+		return ""
+	}
+	if svc.Provider == "aws" {
+		return "com.amazonaws.vpce." + svc.Region + ".vpce-svc-" + svc.ID
+	}
+	return "projects/skysqltest/regions/" + svc.Region + "/serviceAttachments/" + svc.ID
+}
+
+// paginate returns the page of values selected by the page and page_size query parameters.
+// Pages are numbered from 1, and all values are returned when page_size is not set.
+func paginate[T any](values []T, query url.Values) []T {
+	size, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil || size <= 0 {
+		return values
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	start := (page - 1) * size
+	if start >= len(values) {
+		return values[:0]
+	}
+	end := start + size
+	if end > len(values) {
+		end = len(values)
+	}
+	return values[start:end]
+}
+
+func decode(w http.ResponseWriter, req *http.Request, value interface{}) bool {
+	if err := json.NewDecoder(req.Body).Decode(value); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, skysql.ErrorResponse{
+		Code: statusCode,
+		Errors: []skysql.ErrorDetails{
+			{Error: http.StatusText(statusCode), Message: message},
+		},
+	})
+}
@@ -0,0 +1,388 @@
+// Package skysqltest provides a stateful in-memory fake of the SkySQL API for tests.
+//
+// The server models services, allow lists, endpoints, power state, autonomous actions,
+// projects, versions and availability zones. Services move through a configurable state
+// machine on every read, and faults such as latency, error statuses and dropped responses
+// can be injected per endpoint.
+package skysqltest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/autonomous"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+)
+
+// StateMachine defines the statuses a service goes through after an operation.
+// Each read of the service moves it to the next status of the sequence.
+type StateMachine struct {
+	// CreateStates are reported after a service is created. The create response has the pending_create status.
+	CreateStates []string
+	// UpdateStates are reported after the size, nodes, storage, endpoints or allow list are changed.
+	UpdateStates []string
+	// StopStates are reported after a service is stopped.
+	StopStates []string
+	// StartStates are reported after a service is started.
+	StartStates []string
+	// DeleteStates are reported after a service is deleted. The service disappears once they are exhausted.
+	DeleteStates []string
+}
+
+// DefaultStateMachine reaches the final status on the second read after an operation.
+func DefaultStateMachine() StateMachine {
+	return StateMachine{
+		CreateStates: []string{"provisioning", "ready"},
+		UpdateStates: []string{"scaling", "ready"},
+		StopStates:   []string{"stopping", "stopped"},
+		StartStates:  []string{"starting", "ready"},
+		DeleteStates: []string{"pending_delete"},
+	}
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+}
+
+type service struct {
+	provisioning.Service
+	pendingStates []string
+	deleting      bool
+	credentials   provisioning.Credentials
+}
+
+// Server is a fake SkySQL API server.
+type Server struct {
+	// URL is the base URL of the server, e.g. http://127.0.0.1:1234
+	URL string
+
+	mu           sync.Mutex
+	httpServer   *httptest.Server
+	accessToken  string
+	stateMachine StateMachine
+	services     map[string]*service
+	actions      map[string]autonomous.ActionResponse
+	projects     []organization.Project
+	versions     []provisioning.Version
+	sizes        []provisioning.Size
+	regions      []provisioning.Region
+	topologies   []provisioning.Topology
+	storageSizes []provisioning.StorageSize
+	zones        []provisioning.AvailabilityZone
+	faults       []*Fault
+	requests     []Request
+	sequence     int
+}
+
+// Option configures the Server.
+type Option func(*Server)
+
+// WithAccessToken makes the server reject requests without the given bearer token.
+func WithAccessToken(token string) Option {
+	return func(s *Server) {
+		s.accessToken = token
+	}
+}
+
+// WithStateMachine replaces the default state machine.
+func WithStateMachine(stateMachine StateMachine) Option {
+	return func(s *Server) {
+		s.stateMachine = stateMachine
+	}
+}
+
+// WithProjects replaces the default projects.
+func WithProjects(projects ...organization.Project) Option {
+	return func(s *Server) {
+		s.projects = projects
+	}
+}
+
+// WithVersions replaces the default versions.
+func WithVersions(versions ...provisioning.Version) Option {
+	return func(s *Server) {
+		s.versions = versions
+	}
+}
+
+// WithSizes replaces the default instance sizes.
+func WithSizes(sizes ...provisioning.Size) Option {
+	return func(s *Server) {
+		s.sizes = sizes
+	}
+}
+
+// WithRegions replaces the default regions.
+func WithRegions(regions ...provisioning.Region) Option {
+	return func(s *Server) {
+		s.regions = regions
+	}
+}
+
+// WithTopologies replaces the default topologies.
+func WithTopologies(topologies ...provisioning.Topology) Option {
+	return func(s *Server) {
+		s.topologies = topologies
+	}
+}
+
+// WithStorageSizes replaces the default storage sizes.
+func WithStorageSizes(storageSizes ...provisioning.StorageSize) Option {
+	return func(s *Server) {
+		s.storageSizes = storageSizes
+	}
+}
+
+// WithZones replaces the default availability zones.
+func WithZones(zones ...provisioning.AvailabilityZone) Option {
+	return func(s *Server) {
+		s.zones = zones
+	}
+}
+
+// NewServer starts a new fake server. Close it when the test is done.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		stateMachine: DefaultStateMachine(),
+		services:     make(map[string]*service),
+		actions:      make(map[string]autonomous.ActionResponse),
+		projects: []organization.Project{
+			{Id: "a1b2c3d4-0000-4000-8000-000000000001", Name: "Default", Description: "Default project", IsDefault: true},
+		},
+		versions:     defaultVersions(),
+		sizes:        defaultSizes(),
+		regions:      defaultRegions(),
+		topologies:   defaultTopologies(),
+		storageSizes: defaultStorageSizes(),
+		zones:        defaultZones(),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.httpServer.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// AddService adds a service to the server and returns its ID.
+// A random ID is assigned when the service has none, and the status defaults to ready.
+func (s *Server) AddService(value provisioning.Service) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value.ID == "" {
+		value.ID = s.nextID("db")
+	}
+	if value.Status == "" {
+		value.Status = "ready"
+	}
+	s.services[value.ID] = &service{
+		Service:     value,
+		credentials: s.newCredentials(value.ID, value.FQDN),
+	}
+	return value.ID
+}
+
+// Service returns a copy of the service with the given ID.
+func (s *Server) Service(serviceID string) (provisioning.Service, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svc, ok := s.services[serviceID]
+	if !ok {
+		return provisioning.Service{}, false
+	}
+	return svc.Service, true
+}
+
+// Services returns a copy of all services ordered by ID.
+func (s *Server) Services() []provisioning.Service {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	services := make([]provisioning.Service, 0, len(s.services))
+	for _, svc := range s.services {
+		services = append(services, svc.Service)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+	return services
+}
+
+// SetNextStates overrides the statuses the service reports on the next reads.
+func (s *Server) SetNextStates(serviceID string, states ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if svc, ok := s.services[serviceID]; ok {
+		svc.pendingStates = append([]string(nil), states...)
+	}
+}
+
+// Actions returns a copy of the autonomous actions of the service.
+func (s *Server) Actions(serviceID string) []autonomous.ActionResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.serviceActions(serviceID)
+}
+
+// Requests returns the requests received by the server so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) nextID(prefix string) string {
+	s.sequence++
+	return fmt.Sprintf("%sfake%08d", prefix, s.sequence)
+}
+
+func (s *Server) newCredentials(serviceID string, host string) provisioning.Credentials {
+	s.sequence++
+	return provisioning.Credentials{
+		Username: serviceID,
+		Password: fmt.Sprintf("fake-password-%08d", s.sequence),
+		Host:     host,
+	}
+}
+
+func (s *Server) serviceActions(serviceID string) []autonomous.ActionResponse {
+	actions := make([]autonomous.ActionResponse, 0)
+	for _, action := range s.actions {
+		if action.ServiceID == serviceID {
+			actions = append(actions, action)
+		}
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].ID < actions[j].ID
+	})
+	return actions
+}
+
+func defaultVersions() []provisioning.Version {
+	releaseDate := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	versions := make([]provisioning.Version, 0)
+	for _, topology := range []string{"es-single", "es-replica", "xpand"} {
+		for _, version := range []struct {
+			name    string
+			isMajor bool
+		}{
+			{"10.6.11-6-1", true},
+			{"10.6.12-8-1", false},
+		} {
+			versions = append(versions, provisioning.Version{
+				Id:          topology + "-" + version.name,
+				Name:        version.name,
+				Version:     version.name,
+				Topology:    topology,
+				Product:     "server",
+				DisplayName: "MariaDB Enterprise Server " + version.name,
+				IsMajor:     version.isMajor,
+				ReleaseDate: releaseDate,
+			})
+		}
+	}
+	return versions
+}
+
+func defaultSizes() []provisioning.Size {
+	sizes := make([]provisioning.Size, 0)
+	for _, provider := range []string{"gcp", "aws"} {
+		for _, architecture := range []string{"amd64", "arm64"} {
+			for _, size := range []struct {
+				name      string
+				cpu       int
+				ram       int
+				sizeType  string
+				isDefault bool
+			}{
+				{"sky-2x4", 2, 4, "server", false},
+				{"sky-2x8", 2, 8, "server", true},
+				{"sky-4x16", 4, 16, "server", false},
+				{"sky-8x32", 8, 32, "server", false},
+				{"sky-2x4", 2, 4, "maxscale", true},
+				{"sky-4x16", 4, 16, "maxscale", false},
+			} {
+				sizes = append(sizes, provisioning.Size{
+					ID:           fmt.Sprintf("%s-%s-%s-%s", provider, architecture, size.sizeType, size.name),
+					Name:         size.name,
+					DisplayName:  fmt.Sprintf("%d vCPU, %d GB", size.cpu, size.ram),
+					ServiceType:  "transactional",
+					Provider:     provider,
+					Tier:         "foundation",
+					Architecture: architecture,
+					CPU:          fmt.Sprintf("%d vCPU", size.cpu),
+					RAM:          fmt.Sprintf("%d GB", size.ram),
+					Type:         size.sizeType,
+					Default:      size.isDefault,
+					// The serverless topologies have no instance sizes
+					Topologies: []string{"es-single", "es-replica", "xpand", "csdw"},
+				})
+			}
+		}
+	}
+	return sizes
+}
+
+func defaultRegions() []provisioning.Region {
+	return []provisioning.Region{
+		{ID: "gcp-us-central1", Name: "us-central1", DisplayName: "Iowa", Provider: "gcp"},
+		{ID: "gcp-europe-west1", Name: "europe-west1", DisplayName: "Belgium", Provider: "gcp"},
+		{ID: "aws-us-east-1", Name: "us-east-1", DisplayName: "N. Virginia", Provider: "aws"},
+		{ID: "aws-us-east-2", Name: "us-east-2", DisplayName: "Ohio", Provider: "aws"},
+	}
+}
+
+func defaultTopologies() []provisioning.Topology {
+	return []provisioning.Topology{
+		{ID: "es-single", Name: "es-single", DisplayName: "Enterprise Server Single Node", ServiceType: "transactional", SupportsNoSQL: true},
+		{ID: "es-replica", Name: "es-replica", DisplayName: "Enterprise Server With Replica(s)", ServiceType: "transactional", SupportsMaxscale: true, SupportsReplication: true, SupportsNoSQL: true},
+		{ID: "xpand", Name: "xpand", DisplayName: "Xpand Distributed SQL", ServiceType: "transactional", SupportsMaxscale: true, SupportsReplication: true},
+		{ID: "csdw", Name: "csdw", DisplayName: "ColumnStore Data Warehouse", ServiceType: "analytical", SupportsMaxscale: true},
+		{ID: "sa", Name: "sa", DisplayName: "Serverless Analytics", ServiceType: "analytical"},
+	}
+}
+
+func defaultStorageSizes() []provisioning.StorageSize {
+	storageSizes := make([]provisioning.StorageSize, 0)
+	for _, provider := range []string{"gcp", "aws"} {
+		for _, size := range []int64{100, 200, 300, 400, 500, 1000, 2000, 5000, 10000} {
+			storageSizes = append(storageSizes, provisioning.StorageSize{
+				ID:       fmt.Sprintf("%s-%d", provider, size),
+				Provider: provider,
+				Tier:     "foundation",
+				Size:     size,
+			})
+		}
+	}
+	return storageSizes
+}
+
+func defaultZones() []provisioning.AvailabilityZone {
+	return []provisioning.AvailabilityZone{
+		{ID: "us-central1-a", Name: "us-central1-a", Region: "us-central1", Provider: "gcp"},
+		{ID: "us-central1-b", Name: "us-central1-b", Region: "us-central1", Provider: "gcp"},
+		{ID: "use1-az1", Name: "us-east-1a", Region: "us-east-1", Provider: "aws"},
+		{ID: "use1-az2", Name: "us-east-1b", Region: "us-east-1", Provider: "aws"},
+	}
+}
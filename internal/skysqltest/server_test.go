@@ -0,0 +1,277 @@
+package skysqltest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/autonomous"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/organization"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysql/provisioning"
+	"github.com/mariadb-corporation/terraform-provider-skysql/internal/skysqltest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerServiceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := skysqltest.NewServer(skysqltest.WithAccessToken("[token]"))
+	defer server.Close()
+	client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))
+
+	created, err := client.CreateService(ctx, &provisioning.CreateServiceRequest{
+		Name:      "test-gcp",
+		Provider:  "gcp",
+		Region:    "us-central1",
+		Topology:  "es-single",
+		Size:      "sky-2x8",
+		Nodes:     1,
+		Storage:   100,
+		AllowList: []provisioning.AllowListItem{{IPAddress: "127.0.0.1/32"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending_create", created.Status)
+	require.Equal(t, "pd-ssd", created.StorageVolume.VolumeType)
+
+	var statuses []string
+	for i := 0; i < 2; i++ {
+		service, err := client.GetServiceByID(ctx, created.ID)
+		require.NoError(t, err)
+		statuses = append(statuses, service.Status)
+	}
+	require.Equal(t, []string{"provisioning", "ready"}, statuses)
+
+	found, err := client.FindServicesByName(ctx, "test-gcp", "")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.NotEmpty(t, found[0].FQDN)
+
+	require.NoError(t, client.ModifyServiceSize(ctx, created.ID, "sky-4x16"))
+	service, err := client.GetServiceByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, "scaling", service.Status)
+	require.Equal(t, "sky-4x16", service.Size)
+
+	require.NoError(t, client.UpgradeServiceVersion(ctx, created.ID, "10.6.12-8-1"))
+	require.ErrorIs(t, client.UpgradeServiceVersion(ctx, created.ID, "8.0.23"), skysql.ErrorValidation)
+	service, err = client.GetServiceByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, "10.6.12-8-1", service.Version)
+
+	require.NoError(t, client.SetServicePowerState(ctx, created.ID, false))
+	service, _ = client.GetServiceByID(ctx, created.ID)
+	service, _ = client.GetServiceByID(ctx, created.ID)
+	require.Equal(t, "stopped", service.Status)
+	require.False(t, service.IsActive)
+
+	allowList, err := client.UpdateServiceAllowListByID(ctx, created.ID, []provisioning.AllowListItem{{IPAddress: "10.0.0.0/8"}})
+	require.NoError(t, err)
+	require.Equal(t, []provisioning.AllowListItem{{IPAddress: "10.0.0.0/8"}}, allowList)
+
+	endpoint, err := client.ModifyServiceEndpoints(ctx, created.ID, "privateconnect", []string{"project-1"}, "private")
+	require.NoError(t, err)
+	require.Equal(t, "projects/skysqltest/regions/us-central1/serviceAttachments/"+created.ID, endpoint.EndpointService)
+
+	_, err = client.SetAutonomousActions(ctx, autonomous.SetAutonomousActionsRequest{
+		ServiceID: created.ID,
+		Actions:   []autonomous.AutoScaleAction{autonomous.NewAutoScaleDiskAction(200)},
+	})
+	require.NoError(t, err)
+	actions, err := client.GetAutonomousActions(ctx, created.ID)
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+
+	require.NoError(t, client.DeleteServiceByID(ctx, created.ID))
+	service, err = client.GetServiceByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, "pending_delete", service.Status)
+	_, err = client.GetServiceByID(ctx, created.ID)
+	require.ErrorIs(t, err, skysql.ErrorServiceNotFound)
+	require.Empty(t, server.Actions(created.ID))
+}
+
+func TestServerStateMachine(t *testing.T) {
+	ctx := context.Background()
+	stateMachine := skysqltest.DefaultStateMachine()
+	stateMachine.CreateStates = []string{"provisioning", "provisioning", "failed"}
+	server := skysqltest.NewServer(skysqltest.WithStateMachine(stateMachine))
+	defer server.Close()
+	client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))
+
+	created, err := client.CreateService(ctx, &provisioning.CreateServiceRequest{
+		Name: "test", Provider: "aws", Region: "us-east-1", Topology: "es-replica",
+	})
+	require.NoError(t, err)
+
+	var statuses []string
+	for i := 0; i < 4; i++ {
+		service, err := client.GetServiceByID(ctx, created.ID)
+		require.NoError(t, err)
+		statuses = append(statuses, service.Status)
+	}
+	require.Equal(t, []string{"provisioning", "provisioning", "failed", "failed"}, statuses)
+
+	server.SetNextStates(created.ID, "ready")
+	service, err := client.GetServiceByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, "ready", service.Status)
+}
+
+func TestServerFaults(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rate limited requests are retried", func(t *testing.T) {
+		server := skysqltest.NewServer()
+		defer server.Close()
+		server.InjectFault(skysqltest.Fault{Method: http.MethodGet, Path: "/organization/v1/projects", StatusCode: http.StatusTooManyRequests, RetryAfter: "0", Times: 2})
+		client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(2, time.Millisecond))
+
+		projects, err := client.GetProjects(ctx)
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		require.Len(t, server.Requests(), 3)
+	})
+
+	t.Run("server errors are returned", func(t *testing.T) {
+		server := skysqltest.NewServer()
+		defer server.Close()
+		server.InjectFault(skysqltest.Fault{Path: "/provisioning/v1/versions", StatusCode: http.StatusInternalServerError})
+		client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))
+
+		_, err := client.GetVersions(ctx)
+		var apiErr *skysql.APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	})
+
+	t.Run("dropped responses still change the state", func(t *testing.T) {
+		server := skysqltest.NewServer()
+		defer server.Close()
+		server.InjectFault(skysqltest.Fault{Method: http.MethodPost, Path: "/provisioning/v1/services", Drop: true, Times: 1})
+		client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))
+
+		_, err := client.CreateService(ctx, &provisioning.CreateServiceRequest{
+			Name: "test", Provider: "gcp", Region: "us-central1", Topology: "es-single",
+		})
+		require.Error(t, err)
+		require.Len(t, server.Services(), 1)
+	})
+
+	t.Run("latency is honored by the request context", func(t *testing.T) {
+		server := skysqltest.NewServer()
+		defer server.Close()
+		server.InjectFault(skysqltest.Fault{Latency: time.Second})
+		client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := client.GetProjects(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestServerRejectsInvalidToken(t *testing.T) {
+	server := skysqltest.NewServer(skysqltest.WithAccessToken("[token]"))
+	defer server.Close()
+	client := skysql.New(server.URL, "[wrong]", skysql.WithRetryPolicy(0, 0))
+
+	_, err := client.GetProjects(context.Background())
+	require.ErrorIs(t, err, skysql.ErrorUnauthorized)
+}
+
+func TestServerListServicesPagination(t *testing.T) {
+	server := skysqltest.NewServer()
+	defer server.Close()
+	for i := 0; i < 2*skysql.DefaultPageSize+5; i++ {
+		server.AddService(provisioning.Service{Name: fmt.Sprintf("service-%03d", i), ProjectID: "project-1"})
+	}
+	server.AddService(provisioning.Service{Name: "other", ProjectID: "project-2"})
+	client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))
+
+	services, err := client.ListServices(context.Background(), func(values url.Values) {
+		values.Set("project_id", "project-1")
+	})
+	require.NoError(t, err)
+	require.Len(t, services, 2*skysql.DefaultPageSize+5)
+	require.Len(t, server.Requests(), 3)
+}
+
+func TestServerProjects(t *testing.T) {
+	ctx := context.Background()
+	server := skysqltest.NewServer()
+	defer server.Close()
+	client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))
+
+	project, err := client.CreateProject(ctx, &organization.CreateProjectRequest{Name: "team-a", IsDefault: true})
+	require.NoError(t, err)
+	require.True(t, project.IsDefault)
+
+	_, err = client.CreateProject(ctx, &organization.CreateProjectRequest{Name: "team-a"})
+	require.ErrorIs(t, err, skysql.ErrorConflict)
+
+	project, err = client.UpdateProject(ctx, project.Id, &organization.UpdateProjectRequest{Name: "team-b", Description: "Team B"})
+	require.NoError(t, err)
+	require.Equal(t, "team-b", project.Name)
+	require.False(t, project.IsDefault)
+
+	server.AddService(provisioning.Service{Name: "service", ProjectID: project.Id})
+	require.ErrorIs(t, client.DeleteProject(ctx, project.Id), skysql.ErrorConflict)
+
+	require.ErrorIs(t, client.DeleteProject(ctx, "unknown"), skysql.ErrorNotFound)
+}
+
+func TestServerSizes(t *testing.T) {
+	server := skysqltest.NewServer()
+	defer server.Close()
+	client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))
+
+	sizes, err := client.GetSizes(context.Background(), func(values url.Values) {
+		values.Set("provider", "aws")
+		values.Set("architecture", "arm64")
+		values.Set("type", "maxscale")
+	})
+	require.NoError(t, err)
+	require.Len(t, sizes, 2)
+	for _, size := range sizes {
+		require.Equal(t, "aws", size.Provider)
+		require.Equal(t, "maxscale", size.Type)
+	}
+
+	sizes, err = client.GetSizes(context.Background(), func(values url.Values) {
+		values.Set("provider", "aws")
+		values.Set("architecture", "arm64")
+		values.Set("topology", "es-single")
+	})
+	require.NoError(t, err)
+	require.Len(t, sizes, 6)
+
+	sizes, err = client.GetSizes(context.Background(), func(values url.Values) {
+		values.Set("topology", "sa")
+	})
+	require.NoError(t, err)
+	require.Empty(t, sizes, "the serverless topologies have no instance sizes")
+}
+
+func TestServerRegionsAndTopologies(t *testing.T) {
+	ctx := context.Background()
+	server := skysqltest.NewServer()
+	defer server.Close()
+	client := skysql.New(server.URL, "[token]", skysql.WithRetryPolicy(0, 0))
+
+	regions, err := client.GetRegions(ctx, func(values url.Values) {
+		values.Set("provider", "aws")
+	})
+	require.NoError(t, err)
+	require.Len(t, regions, 2)
+
+	topologies, err := client.GetTopologies(ctx, func(values url.Values) {
+		values.Set("service_type", "analytical")
+	})
+	require.NoError(t, err)
+	require.Len(t, topologies, 2)
+	require.False(t, topologies[1].SupportsMaxscale)
+}
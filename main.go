@@ -38,7 +38,18 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+
+	shutdownTracing, err := provider.SetupTracing(ctx, version)
+	if err != nil {
+		// Tracing is optional, the provider runs without it
+		log.Printf("[WARN] Tracing is turned off: %s", err)
+	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("[WARN] Unable to flush the traces: %s", shutdownErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())